@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type CACertificate struct {
+	ID         string   `json:"id,omitempty"`
+	Cert       string   `json:"cert,omitempty"`
+	CertDigest string   `json:"cert_digest,omitempty"`
+	Tags       []string `json:"tags"`
+}
+
+// CACertificateService handles the /ca_certificates endpoints.
+type CACertificateService struct {
+	client *Client
+}
+
+func (s *CACertificateService) Create(ctx context.Context, caCertificate *CACertificate) (*CACertificate, error) {
+	created := new(CACertificate)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(caCertificate).Post("ca_certificates/"), created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *CACertificateService) Get(ctx context.Context, id string) (*CACertificate, error) {
+	caCertificate := new(CACertificate)
+	err := s.client.do(ctx, s.client.newRequest().Path("ca_certificates/").Get(escape(id)), caCertificate)
+	if err != nil {
+		return nil, err
+	}
+
+	return caCertificate, nil
+}
+
+func (s *CACertificateService) Update(ctx context.Context, caCertificate *CACertificate) (*CACertificate, error) {
+	updated := new(CACertificate)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(caCertificate).Path("ca_certificates/").Patch(escape(caCertificate.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *CACertificateService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, s.client.newRequest().Path("ca_certificates/").Delete(escape(id)), nil)
+}
+
+func (s *CACertificateService) List(ctx context.Context, opt *ListOptions) ([]*CACertificate, error) {
+	var caCertificates []*CACertificate
+	err := s.client.list(ctx, "ca_certificates/", opt, func(data json.RawMessage) error {
+		var page []*CACertificate
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		caCertificates = append(caCertificates, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return caCertificates, nil
+}
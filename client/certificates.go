@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Certificate struct {
+	ID      string   `json:"id,omitempty"`
+	Cert    string   `json:"cert,omitempty"`
+	Key     string   `json:"key,omitempty"`
+	CertAlt string   `json:"cert_alt,omitempty"`
+	KeyAlt  string   `json:"key_alt,omitempty"`
+	Tags    []string `json:"tags"`
+}
+
+// CertificateService handles the /certificates endpoints.
+type CertificateService struct {
+	client *Client
+}
+
+func (s *CertificateService) Create(ctx context.Context, certificate *Certificate) (*Certificate, error) {
+	created := new(Certificate)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(certificate).Post("certificates/"), created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *CertificateService) Get(ctx context.Context, id string) (*Certificate, error) {
+	certificate := new(Certificate)
+	err := s.client.do(ctx, s.client.newRequest().Path("certificates/").Get(escape(id)), certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	return certificate, nil
+}
+
+func (s *CertificateService) Update(ctx context.Context, certificate *Certificate) (*Certificate, error) {
+	updated := new(Certificate)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(certificate).Path("certificates/").Patch(escape(certificate.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *CertificateService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, s.client.newRequest().Path("certificates/").Delete(escape(id)), nil)
+}
+
+func (s *CertificateService) List(ctx context.Context, opt *ListOptions) ([]*Certificate, error) {
+	var certificates []*Certificate
+	err := s.client.list(ctx, "certificates/", opt, func(data json.RawMessage) error {
+		var page []*Certificate
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		certificates = append(certificates, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
@@ -0,0 +1,207 @@
+// Package client is a typed client for the Kong Admin API.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/dghubble/sling"
+)
+
+// Client talks to a single Kong Admin API. Entities are reached through the
+// per-entity services, e.g. client.Services.Get(ctx, "my-service").
+type Client struct {
+	root      *sling.Sling
+	sling     *sling.Sling
+	workspace string
+	node      *nodeCache
+
+	Services       *ServiceService
+	Routes         *RouteService
+	Consumers      *ConsumerService
+	Plugins        *PluginService
+	Certificates   *CertificateService
+	CACertificates *CACertificateService
+	SNIs           *SNIService
+	Upstreams      *UpstreamService
+}
+
+// New returns a Client sending its requests through s. The sling must carry
+// the base address of the Admin API and any authentication it requires.
+func New(s *sling.Sling) *Client {
+	return newClient(s, s, "", &nodeCache{})
+}
+
+func newClient(root, s *sling.Sling, workspace string, node *nodeCache) *Client {
+	c := &Client{root: root, sling: s, workspace: workspace, node: node}
+
+	c.Services = &ServiceService{client: c}
+	c.Routes = &RouteService{client: c}
+	c.Consumers = &ConsumerService{client: c}
+	c.Plugins = &PluginService{client: c}
+	c.Certificates = &CertificateService{client: c}
+	c.CACertificates = &CACertificateService{client: c}
+	c.SNIs = &SNIService{client: c}
+	c.Upstreams = &UpstreamService{client: c}
+
+	return c
+}
+
+// Workspace returns a Client sending its requests to the given Kong Enterprise
+// workspace, i.e. with every path prefixed by /{workspace}/. An empty name
+// targets the Admin API root, which Kong maps to the default workspace.
+func (c *Client) Workspace(name string) *Client {
+	if name == c.workspace {
+		return c
+	}
+	if name == "" {
+		return newClient(c.root, c.root, "", c.node)
+	}
+
+	return newClient(c.root, c.root.New().Path(escape(name)+"/"), name, c.node)
+}
+
+// WorkspaceName returns the workspace the Client sends its requests to, empty
+// when it targets the Admin API root.
+func (c *Client) WorkspaceName() string {
+	return c.workspace
+}
+
+// Reference points at another entity, serialized as {"id": "..."}.
+type Reference struct {
+	ID string `json:"id"`
+}
+
+// ListOptions narrows down the entities returned by a List call.
+type ListOptions struct {
+	// Tags only returns entities carrying every one of the given tags.
+	Tags []string
+
+	// MatchAnyTag returns the entities carrying at least one of Tags instead.
+	MatchAnyTag bool
+}
+
+// Match reports whether an entity carrying tags is listed with the options.
+func (o *ListOptions) Match(tags []string) bool {
+	if o == nil || len(o.Tags) == 0 {
+		return true
+	}
+
+	carried := map[string]bool{}
+	for _, tag := range tags {
+		carried[tag] = true
+	}
+
+	for _, tag := range o.Tags {
+		if carried[tag] == o.MatchAnyTag {
+			return o.MatchAnyTag
+		}
+	}
+
+	return !o.MatchAnyTag
+}
+
+type listQuery struct {
+	Offset string `url:"offset,omitempty"`
+	Tags   string `url:"tags,omitempty"`
+}
+
+type listPage struct {
+	Data   json.RawMessage `json:"data"`
+	Offset string          `json:"offset,omitempty"`
+}
+
+func (o *ListOptions) query() *listQuery {
+	q := &listQuery{}
+	if o == nil {
+		return q
+	}
+
+	// Kong separates the tags with "," to match all of them and with "/" to
+	// match any of them, and doesn't support mixing both.
+	separator := ","
+	if o.MatchAnyTag {
+		separator = "/"
+	}
+	q.Tags = strings.Join(o.Tags, separator)
+
+	return q
+}
+
+func (c *Client) newRequest() *sling.Sling {
+	return c.sling.New()
+}
+
+// do sends the request built by s and decodes a successful response into v.
+// Any non-2xx response is returned as an *Error carrying Kong's error body.
+func (c *Client) do(ctx context.Context, s *sling.Sling, v interface{}) error {
+	req, err := s.Request()
+	if err != nil {
+		return err
+	}
+
+	failure := new(Error)
+	response, err := s.Do(req.WithContext(ctx), v, failure)
+	if response != nil && (response.StatusCode < 200 || response.StatusCode > 299) {
+		// A body that isn't Kong's JSON error (e.g. an HTML page from a
+		// proxy in front of the Admin API) still yields a status error.
+		return newError(req, response, failure)
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// list walks every page of the collection at path, handing the raw data of
+// each page to appendPage.
+func (c *Client) list(ctx context.Context, path string, opt *ListOptions, appendPage func(data json.RawMessage) error) error {
+	q := opt.query()
+
+	for {
+		page := &listPage{}
+		if err := c.do(ctx, c.newRequest().Get(path).QueryStruct(q), page); err != nil {
+			return err
+		}
+
+		if len(page.Data) > 0 {
+			if err := appendPage(page.Data); err != nil {
+				return err
+			}
+		}
+
+		if page.Offset == "" {
+			return nil
+		}
+		q.Offset = page.Offset
+	}
+}
+
+// escape makes an id or name safe to use as a single path segment. Colons,
+// as in target addresses, are escaped too since a relative reference whose
+// first segment holds a colon would be parsed as a scheme.
+// marshalWithNull returns the JSON of the entity v, the given fields being
+// set to null.
+func marshalWithNull(v interface{}, null []string) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(null) == 0 {
+		return b, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range null {
+		fields[field] = json.RawMessage("null")
+	}
+
+	return json.Marshal(fields)
+}
+
+func escape(idOrName string) string {
+	return strings.ReplaceAll(url.PathEscape(idOrName), ":", "%3A")
+}
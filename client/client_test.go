@@ -0,0 +1,25 @@
+package client
+
+import "testing"
+
+func TestListOptionsMatch(t *testing.T) {
+	tests := []struct {
+		opt   *ListOptions
+		tags  []string
+		match bool
+	}{
+		{nil, nil, true},
+		{&ListOptions{}, []string{"a"}, true},
+		{&ListOptions{Tags: []string{"a", "b"}}, []string{"a", "b", "c"}, true},
+		{&ListOptions{Tags: []string{"a", "b"}}, []string{"a"}, false},
+		{&ListOptions{Tags: []string{"a", "b"}, MatchAnyTag: true}, []string{"b"}, true},
+		{&ListOptions{Tags: []string{"a", "b"}, MatchAnyTag: true}, []string{"c"}, false},
+		{&ListOptions{Tags: []string{"a"}}, nil, false},
+	}
+
+	for _, test := range tests {
+		if match := test.opt.Match(test.tags); match != test.match {
+			t.Errorf("%+v matches %v = %v, want %v", test.opt, test.tags, match, test.match)
+		}
+	}
+}
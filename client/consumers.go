@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Consumer struct {
+	ID       string   `json:"id,omitempty"`
+	Username string   `json:"username,omitempty"`
+	CustomID string   `json:"custom_id,omitempty"`
+	Tags     []string `json:"tags"`
+}
+
+// ConsumerService handles the /consumers endpoints.
+type ConsumerService struct {
+	client *Client
+}
+
+func (s *ConsumerService) Create(ctx context.Context, consumer *Consumer) (*Consumer, error) {
+	created := new(Consumer)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(consumer).Post("consumers/"), created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *ConsumerService) Get(ctx context.Context, idOrUsername string) (*Consumer, error) {
+	consumer := new(Consumer)
+	err := s.client.do(ctx, s.client.newRequest().Path("consumers/").Get(escape(idOrUsername)), consumer)
+	if err != nil {
+		return nil, err
+	}
+
+	return consumer, nil
+}
+
+// GetByCustomID returns the consumer with the given custom_id, nil when there
+// is none.
+func (s *ConsumerService) GetByCustomID(ctx context.Context, customID string) (*Consumer, error) {
+	query := &struct {
+		CustomID string `url:"custom_id"`
+	}{CustomID: customID}
+
+	page := &struct {
+		Data []*Consumer `json:"data"`
+	}{}
+	err := s.client.do(ctx, s.client.newRequest().Get("consumers/").QueryStruct(query), page)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(page.Data) == 0 {
+		return nil, nil
+	}
+
+	return page.Data[0], nil
+}
+
+func (s *ConsumerService) Update(ctx context.Context, consumer *Consumer) (*Consumer, error) {
+	updated := new(Consumer)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(consumer).Path("consumers/").Patch(escape(consumer.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *ConsumerService) Delete(ctx context.Context, idOrUsername string) error {
+	return s.client.do(ctx, s.client.newRequest().Path("consumers/").Delete(escape(idOrUsername)), nil)
+}
+
+func (s *ConsumerService) List(ctx context.Context, opt *ListOptions) ([]*Consumer, error) {
+	var consumers []*Consumer
+	err := s.client.list(ctx, "consumers/", opt, func(data json.RawMessage) error {
+		var page []*Consumer
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		consumers = append(consumers, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return consumers, nil
+}
+
+// JWTCredentials returns the service managing the JWT credentials of the
+// given consumer (id or username).
+func (s *ConsumerService) JWTCredentials(consumer string) *JWTCredentialService {
+	return &JWTCredentialService{client: s.client, consumer: consumer}
+}
+
+// KeyAuthCredentials returns the service managing the key-auth credentials of
+// the given consumer (id or username).
+func (s *ConsumerService) KeyAuthCredentials(consumer string) *KeyAuthCredentialService {
+	return &KeyAuthCredentialService{client: s.client, consumer: consumer}
+}
+
+// BasicAuthCredentials returns the service managing the basic-auth
+// credentials of the given consumer (id or username).
+func (s *ConsumerService) BasicAuthCredentials(consumer string) *BasicAuthCredentialService {
+	return &BasicAuthCredentialService{client: s.client, consumer: consumer}
+}
+
+// ACLGroups returns the service managing the ACL groups of the given consumer
+// (id or username).
+func (s *ConsumerService) ACLGroups(consumer string) *ConsumerACLGroupService {
+	return &ConsumerACLGroupService{client: s.client, consumer: consumer}
+}
@@ -0,0 +1,307 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type JWTCredential struct {
+	ID           string   `json:"id,omitempty"`
+	Key          string   `json:"key,omitempty"`
+	Algorithm    string   `json:"algorithm,omitempty"`
+	RSAPublicKey string   `json:"rsa_public_key,omitempty"`
+	Secret       string   `json:"secret,omitempty"`
+	Consumer     string   `json:"-"`
+	Tags         []string `json:"tags"`
+}
+
+type KeyAuthCredential struct {
+	ID       string   `json:"id,omitempty"`
+	Key      string   `json:"key,omitempty"`
+	Consumer string   `json:"-"`
+	TTL      int      `json:"ttl,omitempty"`
+	Tags     []string `json:"tags"`
+}
+
+type BasicAuthCredential struct {
+	ID       string   `json:"id,omitempty"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	Consumer string   `json:"-"`
+	Tags     []string `json:"tags"`
+}
+
+type ConsumerACLGroup struct {
+	ID       string   `json:"id,omitempty"`
+	Group    string   `json:"group,omitempty"`
+	Consumer string   `json:"-"`
+	Tags     []string `json:"tags"`
+}
+
+// JWTCredentialService handles the /consumers/{consumer}/jwt endpoints.
+type JWTCredentialService struct {
+	client   *Client
+	consumer string
+}
+
+func (s *JWTCredentialService) path() string {
+	return "consumers/" + escape(s.consumer) + "/jwt/"
+}
+
+func (s *JWTCredentialService) Create(ctx context.Context, jwtCredential *JWTCredential) (*JWTCredential, error) {
+	created := new(JWTCredential)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(jwtCredential).Post(s.path()), created)
+	if err != nil {
+		return nil, err
+	}
+	created.Consumer = s.consumer
+
+	return created, nil
+}
+
+func (s *JWTCredentialService) Get(ctx context.Context, id string) (*JWTCredential, error) {
+	jwtCredential := new(JWTCredential)
+	err := s.client.do(ctx, s.client.newRequest().Path(s.path()).Get(escape(id)), jwtCredential)
+	if err != nil {
+		return nil, err
+	}
+	jwtCredential.Consumer = s.consumer
+
+	return jwtCredential, nil
+}
+
+func (s *JWTCredentialService) Update(ctx context.Context, jwtCredential *JWTCredential) (*JWTCredential, error) {
+	updated := new(JWTCredential)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(jwtCredential).Path(s.path()).Patch(escape(jwtCredential.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+	updated.Consumer = s.consumer
+
+	return updated, nil
+}
+
+func (s *JWTCredentialService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, s.client.newRequest().Path(s.path()).Delete(escape(id)), nil)
+}
+
+func (s *JWTCredentialService) List(ctx context.Context, opt *ListOptions) ([]*JWTCredential, error) {
+	var jwtCredentials []*JWTCredential
+	err := s.client.list(ctx, s.path(), opt, func(data json.RawMessage) error {
+		var page []*JWTCredential
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		for _, jwtCredential := range page {
+			jwtCredential.Consumer = s.consumer
+		}
+		jwtCredentials = append(jwtCredentials, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jwtCredentials, nil
+}
+
+// KeyAuthCredentialService handles the /consumers/{consumer}/key-auth endpoints.
+type KeyAuthCredentialService struct {
+	client   *Client
+	consumer string
+}
+
+func (s *KeyAuthCredentialService) path() string {
+	return "consumers/" + escape(s.consumer) + "/key-auth/"
+}
+
+func (s *KeyAuthCredentialService) Create(ctx context.Context, keyAuthCredential *KeyAuthCredential) (*KeyAuthCredential, error) {
+	created := new(KeyAuthCredential)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(keyAuthCredential).Post(s.path()), created)
+	if err != nil {
+		return nil, err
+	}
+	created.Consumer = s.consumer
+
+	return created, nil
+}
+
+func (s *KeyAuthCredentialService) Get(ctx context.Context, id string) (*KeyAuthCredential, error) {
+	keyAuthCredential := new(KeyAuthCredential)
+	err := s.client.do(ctx, s.client.newRequest().Path(s.path()).Get(escape(id)), keyAuthCredential)
+	if err != nil {
+		return nil, err
+	}
+	keyAuthCredential.Consumer = s.consumer
+
+	return keyAuthCredential, nil
+}
+
+func (s *KeyAuthCredentialService) Update(ctx context.Context, keyAuthCredential *KeyAuthCredential) (*KeyAuthCredential, error) {
+	updated := new(KeyAuthCredential)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(keyAuthCredential).Path(s.path()).Patch(escape(keyAuthCredential.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+	updated.Consumer = s.consumer
+
+	return updated, nil
+}
+
+func (s *KeyAuthCredentialService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, s.client.newRequest().Path(s.path()).Delete(escape(id)), nil)
+}
+
+func (s *KeyAuthCredentialService) List(ctx context.Context, opt *ListOptions) ([]*KeyAuthCredential, error) {
+	var keyAuthCredentials []*KeyAuthCredential
+	err := s.client.list(ctx, s.path(), opt, func(data json.RawMessage) error {
+		var page []*KeyAuthCredential
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		for _, keyAuthCredential := range page {
+			keyAuthCredential.Consumer = s.consumer
+		}
+		keyAuthCredentials = append(keyAuthCredentials, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keyAuthCredentials, nil
+}
+
+// BasicAuthCredentialService handles the /consumers/{consumer}/basic-auth endpoints.
+type BasicAuthCredentialService struct {
+	client   *Client
+	consumer string
+}
+
+func (s *BasicAuthCredentialService) path() string {
+	return "consumers/" + escape(s.consumer) + "/basic-auth/"
+}
+
+func (s *BasicAuthCredentialService) Create(ctx context.Context, basicAuthCredential *BasicAuthCredential) (*BasicAuthCredential, error) {
+	created := new(BasicAuthCredential)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(basicAuthCredential).Post(s.path()), created)
+	if err != nil {
+		return nil, err
+	}
+	created.Consumer = s.consumer
+
+	return created, nil
+}
+
+func (s *BasicAuthCredentialService) Get(ctx context.Context, id string) (*BasicAuthCredential, error) {
+	basicAuthCredential := new(BasicAuthCredential)
+	err := s.client.do(ctx, s.client.newRequest().Path(s.path()).Get(escape(id)), basicAuthCredential)
+	if err != nil {
+		return nil, err
+	}
+	basicAuthCredential.Consumer = s.consumer
+
+	return basicAuthCredential, nil
+}
+
+func (s *BasicAuthCredentialService) Update(ctx context.Context, basicAuthCredential *BasicAuthCredential) (*BasicAuthCredential, error) {
+	updated := new(BasicAuthCredential)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(basicAuthCredential).Path(s.path()).Patch(escape(basicAuthCredential.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+	updated.Consumer = s.consumer
+
+	return updated, nil
+}
+
+func (s *BasicAuthCredentialService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, s.client.newRequest().Path(s.path()).Delete(escape(id)), nil)
+}
+
+func (s *BasicAuthCredentialService) List(ctx context.Context, opt *ListOptions) ([]*BasicAuthCredential, error) {
+	var basicAuthCredentials []*BasicAuthCredential
+	err := s.client.list(ctx, s.path(), opt, func(data json.RawMessage) error {
+		var page []*BasicAuthCredential
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		for _, basicAuthCredential := range page {
+			basicAuthCredential.Consumer = s.consumer
+		}
+		basicAuthCredentials = append(basicAuthCredentials, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return basicAuthCredentials, nil
+}
+
+// ConsumerACLGroupService handles the /consumers/{consumer}/acls endpoints.
+type ConsumerACLGroupService struct {
+	client   *Client
+	consumer string
+}
+
+func (s *ConsumerACLGroupService) path() string {
+	return "consumers/" + escape(s.consumer) + "/acls/"
+}
+
+func (s *ConsumerACLGroupService) Create(ctx context.Context, consumerACLGroup *ConsumerACLGroup) (*ConsumerACLGroup, error) {
+	created := new(ConsumerACLGroup)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(consumerACLGroup).Post(s.path()), created)
+	if err != nil {
+		return nil, err
+	}
+	created.Consumer = s.consumer
+
+	return created, nil
+}
+
+func (s *ConsumerACLGroupService) Get(ctx context.Context, id string) (*ConsumerACLGroup, error) {
+	consumerACLGroup := new(ConsumerACLGroup)
+	err := s.client.do(ctx, s.client.newRequest().Path(s.path()).Get(escape(id)), consumerACLGroup)
+	if err != nil {
+		return nil, err
+	}
+	consumerACLGroup.Consumer = s.consumer
+
+	return consumerACLGroup, nil
+}
+
+func (s *ConsumerACLGroupService) Update(ctx context.Context, consumerACLGroup *ConsumerACLGroup) (*ConsumerACLGroup, error) {
+	updated := new(ConsumerACLGroup)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(consumerACLGroup).Path(s.path()).Patch(escape(consumerACLGroup.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+	updated.Consumer = s.consumer
+
+	return updated, nil
+}
+
+func (s *ConsumerACLGroupService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, s.client.newRequest().Path(s.path()).Delete(escape(id)), nil)
+}
+
+func (s *ConsumerACLGroupService) List(ctx context.Context, opt *ListOptions) ([]*ConsumerACLGroup, error) {
+	var consumerACLGroups []*ConsumerACLGroup
+	err := s.client.list(ctx, s.path(), opt, func(data json.RawMessage) error {
+		var page []*ConsumerACLGroup
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		for _, consumerACLGroup := range page {
+			consumerACLGroup.Consumer = s.consumer
+		}
+		consumerACLGroups = append(consumerACLGroups, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return consumerACLGroups, nil
+}
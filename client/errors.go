@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error is returned for every response of the Admin API outside the 2xx range.
+// When Kong sent its JSON error body, Code, Name, Message and Fields carry its
+// content.
+type Error struct {
+	StatusCode int    `json:"-"`
+	Status     string `json:"-"`
+	Method     string `json:"-"`
+	Path       string `json:"-"`
+
+	Code    int    `json:"code"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	// Fields mirrors the entity being validated: every failing field maps to
+	// either a message, a list (one entry per element, nil when the element
+	// is valid) or a nested object for record fields. Entity level checks are
+	// reported under the "@entity" key.
+	Fields map[string]interface{} `json:"fields"`
+}
+
+func newError(req *http.Request, response *http.Response, body *Error) *Error {
+	if body == nil {
+		body = &Error{}
+	}
+
+	body.StatusCode = response.StatusCode
+	body.Status = response.Status
+	body.Method = req.Method
+	body.Path = req.URL.Path
+
+	return body
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s %s: %s: %s", e.Method, e.Path, e.Status, e.Message)
+	}
+
+	return fmt.Sprintf("%s %s: unexpected status code received: %s", e.Method, e.Path, e.Status)
+}
+
+// IsNotFound reports whether err is an Admin API 404 Not Found.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an Admin API 409 Conflict, i.e. the
+// entity (or one with the same unique fields) already exists.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsValidation reports whether err is an Admin API 400 Bad Request, which
+// Kong returns when an entity fails its schema validation.
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+func hasStatus(err error, status int) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == status
+}
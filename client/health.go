@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Health states reported by Kong for upstreams, targets and their addresses.
+const (
+	HealthHealthy         = "HEALTHY"
+	HealthUnhealthy       = "UNHEALTHY"
+	HealthDNSError        = "DNS_ERROR"
+	HealthHealthchecksOff = "HEALTHCHECKS_OFF"
+)
+
+// TargetHealth is the health of a target as seen by the balancer of the node
+// answering the request.
+type TargetHealth struct {
+	ID     string   `json:"id"`
+	Target string   `json:"target"`
+	Weight int      `json:"weight"`
+	Health string   `json:"health"`
+	Tags   []string `json:"tags"`
+	Data   struct {
+		Addresses []*AddressHealth `json:"addresses"`
+	} `json:"data"`
+}
+
+// AddressHealth is the health of one of the addresses a target resolves to.
+type AddressHealth struct {
+	IP     string `json:"ip"`
+	Port   int    `json:"port"`
+	Weight int    `json:"weight"`
+	Health string `json:"health"`
+}
+
+// BalancerHealth is the overall health of an upstream.
+type BalancerHealth struct {
+	ID     string `json:"id"`
+	Health string `json:"health"`
+}
+
+// Health returns the health of every target of the given upstream (id or
+// name).
+func (s *UpstreamService) Health(ctx context.Context, upstream string) ([]*TargetHealth, error) {
+	var targets []*TargetHealth
+	err := s.client.list(ctx, "upstreams/"+escape(upstream)+"/health/", nil, func(data json.RawMessage) error {
+		var page []*TargetHealth
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		targets = append(targets, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// BalancerHealth returns the overall health of the given upstream (id or
+// name).
+func (s *UpstreamService) BalancerHealth(ctx context.Context, upstream string) (*BalancerHealth, error) {
+	query := &struct {
+		BalancerHealth int `url:"balancer_health"`
+	}{BalancerHealth: 1}
+
+	response := &struct {
+		Data *BalancerHealth `json:"data"`
+	}{Data: new(BalancerHealth)}
+	err := s.client.do(ctx, s.client.newRequest().Path("upstreams/"+escape(upstream)+"/").Get("health/").QueryStruct(query), response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
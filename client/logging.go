@@ -0,0 +1,128 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedFields are the JSON fields whose values never reach the logs:
+// credential passwords, secrets and keys, and certificate private keys.
+var redactedFields = map[string]bool{
+	"password":      true,
+	"secret":        true,
+	"client_secret": true,
+	"key":           true,
+	"key_alt":       true,
+}
+
+const redacted = "**REDACTED**"
+
+// LoggingTransport is an http.RoundTripper tracing every Admin API call
+// through tflog: method, path, status and latency at DEBUG level, and the
+// request and response bodies, with secrets redacted, at TRACE level.
+// Headers, which carry the credentials, are never logged.
+type LoggingTransport struct {
+	// Next sends the actual requests, http.DefaultTransport when nil.
+	Next http.RoundTripper
+}
+
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	ctx := req.Context()
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+	if req.URL.RawQuery != "" {
+		fields["query"] = req.URL.RawQuery
+	}
+
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			requestBody, _ := io.ReadAll(body)
+			body.Close()
+			tflog.Trace(ctx, "Kong Admin API request body", withField(fields, "body", redactBody(requestBody)))
+		}
+	}
+
+	start := time.Now()
+	response, err := next.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		tflog.Debug(ctx, "Kong Admin API request failed", withField(fields, "error", err.Error()))
+		return nil, err
+	}
+
+	fields["status"] = response.StatusCode
+	tflog.Debug(ctx, "Kong Admin API request", fields)
+
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	tflog.Trace(ctx, "Kong Admin API response body", withField(fields, "body", redactBody(responseBody)))
+
+	return response, nil
+}
+
+func withField(fields map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	f := make(map[string]interface{}, len(fields)+1)
+	for k, v := range fields {
+		f[k] = v
+	}
+	f[key] = value
+	return f
+}
+
+// redactBody returns body with the values of redactedFields masked. Bodies
+// which aren't JSON are not logged at all, as they can't be redacted.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("<%d bytes of non-JSON body>", len(body))
+	}
+
+	masked, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes of non-JSON body>", len(body))
+	}
+
+	return string(masked)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if redactedFields[key] && field != nil {
+				value[key] = redacted
+			} else {
+				value[key] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+
+	return v
+}
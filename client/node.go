@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	EditionCommunity  = "community"
+	EditionEnterprise = "enterprise"
+)
+
+// NodeInfo is the information returned by GET / about the Kong node serving
+// the Admin API.
+type NodeInfo struct {
+	Version       string            `json:"version"`
+	Hostname      string            `json:"hostname"`
+	NodeID        string            `json:"node_id"`
+	Tagline       string            `json:"tagline"`
+	LuaVersion    string            `json:"lua_version"`
+	Plugins       NodePlugins       `json:"plugins"`
+	Configuration NodeConfiguration `json:"configuration"`
+}
+
+// NodePlugins lists the plugins installed on the node and those configured
+// somewhere in the cluster.
+type NodePlugins struct {
+	// AvailableOnServer maps the installed plugins to true (Kong 2.x) or to
+	// their version and priority (Kong 3.x).
+	AvailableOnServer map[string]json.RawMessage `json:"available_on_server"`
+	EnabledInCluster  []string                   `json:"enabled_in_cluster"`
+}
+
+// Available returns the sorted names of the plugins installed on the node.
+func (p *NodePlugins) Available() []string {
+	names := make([]string, 0, len(p.AvailableOnServer))
+	for name := range p.AvailableOnServer {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NodeConfiguration holds the few settings of the node configuration the
+// provider cares about.
+type NodeConfiguration struct {
+	Database     string `json:"database"`
+	RouterFlavor string `json:"router_flavor"`
+}
+
+// NodeStatus is the information returned by GET /status about the health of
+// the Kong node serving the Admin API.
+type NodeStatus struct {
+	Database struct {
+		Reachable bool `json:"reachable"`
+	} `json:"database"`
+	Memory struct {
+		WorkersLuaVMs  []*WorkerMemory              `json:"workers_lua_vms"`
+		LuaSharedDicts map[string]*SharedDictMemory `json:"lua_shared_dicts"`
+	} `json:"memory"`
+	Server struct {
+		ConnectionsActive   int `json:"connections_active"`
+		ConnectionsReading  int `json:"connections_reading"`
+		ConnectionsWriting  int `json:"connections_writing"`
+		ConnectionsWaiting  int `json:"connections_waiting"`
+		ConnectionsAccepted int `json:"connections_accepted"`
+		ConnectionsHandled  int `json:"connections_handled"`
+		TotalRequests       int `json:"total_requests"`
+	} `json:"server"`
+}
+
+// WorkerMemory is the memory allocated by the Lua VM of a worker, in a human
+// readable unit such as "1.52 MiB".
+type WorkerMemory struct {
+	PID             int    `json:"pid"`
+	HTTPAllocatedGC string `json:"http_allocated_gc"`
+}
+
+// SharedDictMemory is the usage of a shared dictionary, in a human readable
+// unit such as "1.52 MiB".
+type SharedDictMemory struct {
+	AllocatedSlabs string `json:"allocated_slabs"`
+	Capacity       string `json:"capacity"`
+}
+
+// Edition returns EditionEnterprise for Kong Enterprise nodes, which report
+// either a 4 part version (3.4.3.5) or an "-enterprise-edition" suffix
+// (2.8.4.2-enterprise-edition), and EditionCommunity otherwise.
+func (i *NodeInfo) Edition() string {
+	if strings.Contains(i.Version, "enterprise") {
+		return EditionEnterprise
+	}
+
+	numbers := strings.SplitN(i.Version, "-", 2)[0]
+	if len(strings.Split(numbers, ".")) > 3 {
+		return EditionEnterprise
+	}
+
+	return EditionCommunity
+}
+
+// ParsedVersion returns the major, minor and patch numbers of the node version.
+func (i *NodeInfo) ParsedVersion() (Version, error) {
+	return ParseVersion(i.Version)
+}
+
+// Version is a Kong release, reduced to its major, minor and patch numbers.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses the version reported by Kong, ignoring any enterprise
+// build number or pre-release suffix.
+func ParseVersion(version string) (Version, error) {
+	numbers := strings.SplitN(version, "-", 2)[0]
+	parts := strings.Split(numbers, ".")
+	if len(parts) < 2 {
+		return Version{}, fmt.Errorf("unexpected Kong version %q", version)
+	}
+
+	var v Version
+	for i, field := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		if i >= len(parts) {
+			break
+		}
+
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return Version{}, fmt.Errorf("unexpected Kong version %q", version)
+		}
+		*field = n
+	}
+
+	return v, nil
+}
+
+// Compare returns -1, 0 or 1 depending on whether v is older, the same or
+// newer than other.
+func (v Version) Compare(other Version) int {
+	for _, d := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if d < 0 {
+			return -1
+		} else if d > 0 {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// AtLeast reports whether v is other or a newer release.
+func (v Version) AtLeast(other Version) bool {
+	return v.Compare(other) >= 0
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// nodeCache keeps the node information and plugin schemas shared by a Client
+// and the clients of its workspaces.
+type nodeCache struct {
+	mu            sync.Mutex
+	info          *NodeInfo
+	pluginSchemas map[string]*PluginSchema
+}
+
+// Info returns the information about the Kong node. It is requested once and
+// then cached for the lifetime of the Client.
+func (c *Client) Info(ctx context.Context) (*NodeInfo, error) {
+	c.node.mu.Lock()
+	defer c.node.mu.Unlock()
+
+	if c.node.info != nil {
+		return c.node.info, nil
+	}
+
+	info := new(NodeInfo)
+	if err := c.do(ctx, c.root.New().Get(""), info); err != nil {
+		return nil, err
+	}
+	c.node.info = info
+
+	return info, nil
+}
+
+// Status returns the current status of the Kong node. Unlike Info, it is
+// requested on every call.
+func (c *Client) Status(ctx context.Context) (*NodeStatus, error) {
+	status := new(NodeStatus)
+	if err := c.do(ctx, c.root.New().Get("status"), status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
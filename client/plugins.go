@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Plugin : Kong Service/API plugin request object structure
+type Plugin struct {
+	ID            string                 `json:"id,omitempty"`
+	Name          string                 `json:"name,omitempty"`
+	Configuration map[string]interface{} `json:"config,omitempty"`
+	Protocols     []string               `json:"protocols,omitempty"`
+	Service       map[string]string      `json:"service,omitempty"`
+	Route         map[string]string      `json:"route,omitempty"`
+	Consumer      map[string]string      `json:"consumer,omitempty"`
+	Tags          []string               `json:"tags"`
+	Enabled       bool                   `json:"enabled"`
+}
+
+// PluginService handles the /plugins endpoints.
+type PluginService struct {
+	client *Client
+}
+
+func (s *PluginService) Create(ctx context.Context, plugin *Plugin) (*Plugin, error) {
+	created := new(Plugin)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(plugin).Post("plugins/"), created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Validate checks the plugin against the schema of the plugin on the Kong node
+// without creating it. Validation failures are returned as an *Error whose
+// Fields describe the failing fields.
+func (s *PluginService) Validate(ctx context.Context, plugin *Plugin) error {
+	return s.client.do(ctx, s.client.newRequest().BodyJSON(plugin).Post("schemas/plugins/validate"), nil)
+}
+
+// PluginSchema is the schema of a plugin, as returned by
+// GET /schemas/plugins/{name}.
+type PluginSchema struct {
+	Fields []map[string]*SchemaField `json:"fields"`
+}
+
+// SchemaField describes a field of a schema. The fields of a record are listed
+// in the same way as the fields of the schema.
+type SchemaField struct {
+	Type    string                    `json:"type"`
+	Default interface{}               `json:"default"`
+	Fields  []map[string]*SchemaField `json:"fields"`
+}
+
+// Config returns the field describing the plugin configuration, nil when the
+// schema has none.
+func (s *PluginSchema) Config() *SchemaField {
+	return lookupSchemaField(s.Fields, "config")
+}
+
+// Field returns the field of the record with the given name, nil when the
+// record has none.
+func (f *SchemaField) Field(name string) *SchemaField {
+	if f == nil {
+		return nil
+	}
+
+	return lookupSchemaField(f.Fields, name)
+}
+
+func lookupSchemaField(fields []map[string]*SchemaField, name string) *SchemaField {
+	for _, field := range fields {
+		if f, ok := field[name]; ok {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// Schema returns the schema of the plugin with the given name. Schemas are
+// requested once and then cached for the lifetime of the Client.
+func (s *PluginService) Schema(ctx context.Context, name string) (*PluginSchema, error) {
+	s.client.node.mu.Lock()
+	defer s.client.node.mu.Unlock()
+
+	if schema, ok := s.client.node.pluginSchemas[name]; ok {
+		return schema, nil
+	}
+
+	schema := new(PluginSchema)
+	err := s.client.do(ctx, s.client.newRequest().Path("schemas/plugins/").Get(escape(name)), schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.client.node.pluginSchemas == nil {
+		s.client.node.pluginSchemas = map[string]*PluginSchema{}
+	}
+	s.client.node.pluginSchemas[name] = schema
+
+	return schema, nil
+}
+
+func (s *PluginService) Get(ctx context.Context, id string) (*Plugin, error) {
+	plugin := new(Plugin)
+	err := s.client.do(ctx, s.client.newRequest().Path("plugins/").Get(escape(id)), plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	return plugin, nil
+}
+
+func (s *PluginService) Update(ctx context.Context, plugin *Plugin) (*Plugin, error) {
+	updated := new(Plugin)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(plugin).Path("plugins/").Patch(escape(plugin.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *PluginService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, s.client.newRequest().Path("plugins/").Delete(escape(id)), nil)
+}
+
+func (s *PluginService) List(ctx context.Context, opt *ListOptions) ([]*Plugin, error) {
+	var plugins []*Plugin
+	err := s.client.list(ctx, "plugins/", opt, func(data json.RawMessage) error {
+		var page []*Plugin
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		plugins = append(plugins, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return plugins, nil
+}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryTransport is an http.RoundTripper retrying idempotent requests which
+// failed on the network or were answered with a transient status (429, 500,
+// 502, 503, 504), as Kong does while its database fails over or when the
+// Admin API sits behind a rate limited gateway. The other requests, such as
+// the POST and PATCH creating and updating entities, are only retried when
+// Kong can't have processed them: the connection was refused, or the answer
+// was a 429 or a 503.
+//
+// Attempts are spaced by an exponential backoff with jitter, bounded by
+// BackoffMin and BackoffMax. A Retry-After header of the response replaces
+// the backoff, still capped at BackoffMax.
+type RetryTransport struct {
+	// Next sends the actual requests, http.DefaultTransport when nil.
+	Next http.RoundTripper
+
+	MaxRetries int
+	BackoffMin time.Duration
+	BackoffMax time.Duration
+	// Timeout bounds every single attempt, including reading its response
+	// body. Zero means no timeout.
+	Timeout time.Duration
+}
+
+var retryableStatuses = map[int]bool{
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+// unprocessedStatuses are the transient statuses Kong answers without having
+// processed the request.
+var unprocessedStatuses = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusServiceUnavailable: true,
+}
+
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxRetries := t.MaxRetries
+	if req.Body != nil && req.GetBody == nil {
+		maxRetries = 0
+	}
+	idempotent := idempotentMethods[req.Method]
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			// RoundTrippers must not modify the request, so every retry
+			// sends a copy with a fresh body.
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		response, err := t.roundTrip(attemptReq)
+
+		if attempt >= maxRetries || req.Context().Err() != nil {
+			return response, err
+		}
+		if !shouldRetry(idempotent, response, err) {
+			return response, err
+		}
+
+		wait := t.backoff(attempt)
+		if err == nil {
+			if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+				wait = retryAfter
+				if wait > t.BackoffMax {
+					wait = t.BackoffMax
+				}
+			}
+
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether an attempt which returned response or err may
+// be retried.
+func shouldRetry(idempotent bool, response *http.Response, err error) bool {
+	if err != nil {
+		return idempotent || errors.Is(err, syscall.ECONNREFUSED)
+	}
+
+	if idempotent {
+		return retryableStatuses[response.StatusCode]
+	}
+
+	return unprocessedStatuses[response.StatusCode]
+}
+
+func (t *RetryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if t.Timeout <= 0 {
+		return next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.Timeout)
+	response, err := next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// backoff returns the wait before the retry following attempt: the
+// exponential backoff capped at BackoffMax, of which a random half is
+// jittered away so that concurrent applies don't retry in lockstep.
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	backoff := t.BackoffMin
+	for i := 0; i < attempt && backoff < t.BackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > t.BackoffMax {
+		backoff = t.BackoffMax
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter reads a Retry-After header, given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// cancelOnClose releases the per attempt timeout once the response body has
+// been consumed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := &RetryTransport{BackoffMin: 100 * time.Millisecond, BackoffMax: time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, 50 * time.Millisecond, 100 * time.Millisecond},
+		{1, 100 * time.Millisecond, 200 * time.Millisecond},
+		{2, 200 * time.Millisecond, 400 * time.Millisecond},
+		{3, 400 * time.Millisecond, 800 * time.Millisecond},
+		{4, 500 * time.Millisecond, time.Second},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, test := range tests {
+		for i := 0; i < 100; i++ {
+			if wait := transport.backoff(test.attempt); wait < test.min || wait > test.max {
+				t.Fatalf("backoff(%d) = %s, want between %s and %s", test.attempt, wait, test.min, test.max)
+			}
+		}
+	}
+
+	if wait := (&RetryTransport{}).backoff(3); wait != 0 {
+		t.Errorf("backoff without bounds = %s, want 0", wait)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		wait  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"0", 0, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	}
+
+	for _, test := range tests {
+		wait, ok := parseRetryAfter(test.value)
+		if wait != test.wait || ok != test.ok {
+			t.Errorf("parseRetryAfter(%q) = %s, %v, want %s, %v", test.value, wait, ok, test.wait, test.ok)
+		}
+	}
+
+	wait, ok := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	if !ok || wait <= 50*time.Second || wait > time.Minute {
+		t.Errorf("parseRetryAfter(in a minute) = %s, %v", wait, ok)
+	}
+}
+
+func TestRetryTransportRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		statuses []int
+		attempts int
+		status   int
+	}{
+		{"GET retried on 500", http.MethodGet, []int{500, 502, 200}, 3, 200},
+		{"GET not retried on 404", http.MethodGet, []int{404, 200}, 1, 404},
+		{"GET gives up after MaxRetries", http.MethodGet, []int{503, 503, 503, 503, 200}, 4, 503},
+		{"POST retried on 503", http.MethodPost, []int{503, 201}, 2, 201},
+		{"POST retried on 429", http.MethodPost, []int{429, 201}, 2, 201},
+		{"POST not retried on 500", http.MethodPost, []int{500, 201}, 1, 500},
+		{"PATCH retried on 503", http.MethodPatch, []int{503, 200}, 2, 200},
+		{"PATCH not retried on 502", http.MethodPatch, []int{502, 200}, 1, 502},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body bytes.Buffer
+				_, _ = body.ReadFrom(r.Body)
+				if r.Method != http.MethodGet && body.String() != `{"name":"x"}` {
+					t.Errorf("attempt %d sent body %q", attempts, body.String())
+				}
+				w.WriteHeader(test.statuses[attempts])
+				attempts++
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: &RetryTransport{MaxRetries: 3, BackoffMax: time.Millisecond}}
+
+			var body *bytes.Reader
+			if test.method != http.MethodGet {
+				body = bytes.NewReader([]byte(`{"name":"x"}`))
+			}
+			req, _ := http.NewRequest(test.method, server.URL, nil)
+			if body != nil {
+				req, _ = http.NewRequest(test.method, server.URL, body)
+			}
+
+			response, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response.Body.Close()
+
+			if attempts != test.attempts || response.StatusCode != test.status {
+				t.Errorf("got %d attempts and status %d, want %d attempts and status %d", attempts, response.StatusCode, test.attempts, test.status)
+			}
+		})
+	}
+}
+
+// failingTransport fails the first failures round trips with err.
+type failingTransport struct {
+	err      error
+	failures int
+	attempts int
+}
+
+func (t *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.attempts++
+	if t.attempts <= t.failures {
+		return nil, t.err
+	}
+	return &http.Response{StatusCode: http.StatusCreated, Body: http.NoBody, Request: req}, nil
+}
+
+func TestRetryTransportNetworkErrors(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+
+	tests := []struct {
+		name     string
+		method   string
+		err      error
+		attempts int
+		ok       bool
+	}{
+		{"GET retried on reset", http.MethodGet, reset, 2, true},
+		{"POST retried on refused", http.MethodPost, refused, 2, true},
+		{"POST not retried on reset", http.MethodPost, reset, 1, false},
+		{"PATCH retried on refused", http.MethodPatch, refused, 2, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := &failingTransport{err: test.err, failures: 1}
+			transport := &RetryTransport{Next: next, MaxRetries: 3, BackoffMax: time.Millisecond}
+
+			req, _ := http.NewRequest(test.method, "http://kong:8001/services", nil)
+			response, err := transport.RoundTrip(req)
+
+			if next.attempts != test.attempts || (err == nil) != test.ok {
+				t.Errorf("got %d attempts and error %v, want %d attempts", next.attempts, err, test.attempts)
+			}
+			if response != nil {
+				response.Body.Close()
+			}
+		})
+	}
+}
+
+func TestRetryTransportRetryAfterCapped(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{MaxRetries: 1, BackoffMin: time.Millisecond, BackoffMax: 50 * time.Millisecond}}
+
+	start := time.Now()
+	response, err := client.Post(server.URL, "application/json", bytes.NewReader([]byte(`{}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > 5*time.Second {
+		t.Errorf("waited %s, want retry_backoff_max", elapsed)
+	}
+	if response.StatusCode != http.StatusCreated {
+		t.Errorf("status %d, want 201", response.StatusCode)
+	}
+}
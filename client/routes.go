@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Route : Kong Route request object structure
+type Route struct {
+	ID                      string              `json:"id,omitempty"`
+	Name                    string              `json:"name,omitempty"`
+	Protocols               []string            `json:"protocols"`
+	Methods                 []string            `json:"methods"`
+	Hosts                   []string            `json:"hosts"`
+	Paths                   []string            `json:"paths"`
+	Headers                 map[string][]string `json:"headers"`
+	HttpsRedirectStatusCode int                 `json:"https_redirect_status_code,omitempty"`
+	RegexPriority           int                 `json:"regex_priority"`
+	StripPath               bool                `json:"strip_path,omitempty"`
+	PathHandling            string              `json:"path_handling,omitempty"`
+	PreserveHost            bool                `json:"preserve_host,omitempty"`
+	RequestBuffering        *bool               `json:"request_buffering,omitempty"`
+	ResponseBuffering       *bool               `json:"response_buffering,omitempty"`
+	SNIs                    []string            `json:"snis,omitempty"`
+	Sources                 []*RouteEndpoint    `json:"sources"`
+	Destinations            []*RouteEndpoint    `json:"destinations"`
+	Expression              string              `json:"expression,omitempty"`
+	Priority                *int                `json:"priority,omitempty"`
+	Tags                    []string            `json:"tags"`
+	Service                 Reference           `json:"service"`
+	CreatedAt               int                 `json:"created_at,omitempty"`
+
+	// Null lists the fields sent as null, for Kong to clear them on update.
+	Null []string `json:"-"`
+}
+
+// MarshalJSON sends the Null fields of the route as null.
+func (r *Route) MarshalJSON() ([]byte, error) {
+	type route Route
+	return marshalWithNull((*route)(r), r.Null)
+}
+
+// RouteEndpoint matches the source or destination of a stream connection by
+// IP (or CIDR range), port, or both.
+type RouteEndpoint struct {
+	IP   string `json:"ip,omitempty"`
+	Port int    `json:"port,omitempty"`
+}
+
+// RouteService handles the /routes endpoints.
+type RouteService struct {
+	client *Client
+}
+
+func (s *RouteService) Create(ctx context.Context, route *Route) (*Route, error) {
+	created := new(Route)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(route).Post("routes/"), created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *RouteService) Get(ctx context.Context, idOrName string) (*Route, error) {
+	route := new(Route)
+	err := s.client.do(ctx, s.client.newRequest().Path("routes/").Get(escape(idOrName)), route)
+	if err != nil {
+		return nil, err
+	}
+
+	return route, nil
+}
+
+func (s *RouteService) Update(ctx context.Context, route *Route) (*Route, error) {
+	updated := new(Route)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(route).Path("routes/").Patch(escape(route.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *RouteService) Delete(ctx context.Context, idOrName string) error {
+	return s.client.do(ctx, s.client.newRequest().Path("routes/").Delete(escape(idOrName)), nil)
+}
+
+func (s *RouteService) List(ctx context.Context, opt *ListOptions) ([]*Route, error) {
+	var routes []*Route
+	err := s.client.list(ctx, "routes/", opt, func(data json.RawMessage) error {
+		var page []*Route
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		routes = append(routes, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRouteMarshalJSONNull(t *testing.T) {
+	priority := 0
+
+	tests := []struct {
+		route *Route
+		want  map[string]interface{}
+	}{
+		{&Route{ID: "r1"}, map[string]interface{}{}},
+		{&Route{ID: "r1", Expression: `http.path == "/"`, Priority: &priority}, map[string]interface{}{"expression": `http.path == "/"`, "priority": 0.0}},
+		{&Route{ID: "r1", Priority: &priority, Null: []string{"expression"}}, map[string]interface{}{"expression": nil, "priority": 0.0}},
+	}
+
+	for _, test := range tests {
+		b, err := json.Marshal(test.route)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var fields map[string]interface{}
+		if err := json.Unmarshal(b, &fields); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, field := range []string{"expression", "priority"} {
+			want, wanted := test.want[field]
+			got, sent := fields[field]
+			if sent != wanted || got != want {
+				t.Errorf("%s: %s sent as %v (%v), want %v (%v)", b, field, got, sent, want, wanted)
+			}
+		}
+		if fields["id"] != "r1" {
+			t.Errorf("%s: id not sent", b)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Service : Kong Service request object structure
+type Service struct {
+	ID                string     `json:"id,omitempty"`
+	Name              string     `json:"name,omitempty"`
+	Retries           int        `json:"retries,omitempty"`
+	Protocol          string     `json:"protocol,omitempty"`
+	Host              string     `json:"host,omitempty"`
+	Port              int        `json:"port,omitempty"`
+	Path              string     `json:"path,omitempty"`
+	ConnectTimeout    int        `json:"connect_timeout,omitempty"`
+	WriteTimeout      int        `json:"write_timeout,omitempty"`
+	ReadTimeout       int        `json:"read_timeout,omitempty"`
+	Tags              []string   `json:"tags"`
+	ClientCertificate *Reference `json:"client_certificate,omitempty"`
+	TlsVerify         *bool      `json:"tls_verify,omitempty"`
+	TlsVerifyDepth    *int       `json:"tls_verify_depth,omitempty"`
+	CACertificates    []string   `json:"ca_certificates,omitempty"`
+	Enabled           *bool      `json:"enabled,omitempty"`
+
+	// Null lists the fields sent as null, for Kong to use its defaults or to
+	// clear them on update.
+	Null []string `json:"-"`
+}
+
+// MarshalJSON sends the Null fields of the service as null.
+func (s *Service) MarshalJSON() ([]byte, error) {
+	type service Service
+	return marshalWithNull((*service)(s), s.Null)
+}
+
+// ServiceService handles the /services endpoints.
+type ServiceService struct {
+	client *Client
+}
+
+func (s *ServiceService) Create(ctx context.Context, service *Service) (*Service, error) {
+	created := new(Service)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(service).Post("services/"), created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *ServiceService) Get(ctx context.Context, idOrName string) (*Service, error) {
+	service := new(Service)
+	err := s.client.do(ctx, s.client.newRequest().Path("services/").Get(escape(idOrName)), service)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (s *ServiceService) Update(ctx context.Context, service *Service) (*Service, error) {
+	updated := new(Service)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(service).Path("services/").Patch(escape(service.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *ServiceService) Delete(ctx context.Context, idOrName string) error {
+	return s.client.do(ctx, s.client.newRequest().Path("services/").Delete(escape(idOrName)), nil)
+}
+
+func (s *ServiceService) List(ctx context.Context, opt *ListOptions) ([]*Service, error) {
+	var services []*Service
+	err := s.client.list(ctx, "services/", opt, func(data json.RawMessage) error {
+		var page []*Service
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		services = append(services, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
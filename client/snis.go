@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type SNI struct {
+	Name             string      `json:"name,omitempty"`
+	SSLCertificateID Certificate `json:"certificate,omitempty"`
+	Tags             []string    `json:"tags"`
+}
+
+// SNIService handles the /snis endpoints.
+type SNIService struct {
+	client *Client
+}
+
+func (s *SNIService) Create(ctx context.Context, sni *SNI) (*SNI, error) {
+	created := new(SNI)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(sni).Post("snis/"), created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *SNIService) Get(ctx context.Context, name string) (*SNI, error) {
+	sni := new(SNI)
+	err := s.client.do(ctx, s.client.newRequest().Path("snis/").Get(escape(name)), sni)
+	if err != nil {
+		return nil, err
+	}
+
+	return sni, nil
+}
+
+func (s *SNIService) Update(ctx context.Context, sni *SNI) (*SNI, error) {
+	updated := new(SNI)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(sni).Path("snis/").Patch(escape(sni.Name)), updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *SNIService) Delete(ctx context.Context, name string) error {
+	return s.client.do(ctx, s.client.newRequest().Path("snis/").Delete(escape(name)), nil)
+}
+
+func (s *SNIService) List(ctx context.Context, opt *ListOptions) ([]*SNI, error) {
+	var snis []*SNI
+	err := s.client.list(ctx, "snis/", opt, func(data json.RawMessage) error {
+		var page []*SNI
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		snis = append(snis, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snis, nil
+}
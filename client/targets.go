@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Target struct {
+	ID       string   `json:"id,omitempty"`
+	Upstream string   `json:"-"`
+	Target   string   `json:"target,omitempty"`
+	Weight   int      `json:"weight"`
+	Tags     []string `json:"tags"`
+}
+
+// TargetService handles the /upstreams/{upstream}/targets endpoints.
+type TargetService struct {
+	client   *Client
+	upstream string
+}
+
+func (s *TargetService) path() string {
+	return "upstreams/" + escape(s.upstream) + "/targets/"
+}
+
+func (s *TargetService) Create(ctx context.Context, target *Target) (*Target, error) {
+	created := new(Target)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(target).Post(s.path()), created)
+	if err != nil {
+		return nil, err
+	}
+	created.Upstream = s.upstream
+
+	return created, nil
+}
+
+func (s *TargetService) Get(ctx context.Context, id string) (*Target, error) {
+	target := new(Target)
+	err := s.client.do(ctx, s.client.newRequest().Path(s.path()).Get(escape(id)), target)
+	if err != nil {
+		return nil, err
+	}
+	target.Upstream = s.upstream
+
+	return target, nil
+}
+
+func (s *TargetService) Update(ctx context.Context, target *Target) (*Target, error) {
+	updated := new(Target)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(target).Path(s.path()).Patch(escape(target.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+	updated.Upstream = s.upstream
+
+	return updated, nil
+}
+
+func (s *TargetService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, s.client.newRequest().Path(s.path()).Delete(escape(id)), nil)
+}
+
+func (s *TargetService) List(ctx context.Context, opt *ListOptions) ([]*Target, error) {
+	var targets []*Target
+	err := s.client.list(ctx, s.path(), opt, func(data json.RawMessage) error {
+		var page []*Target
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		for _, target := range page {
+			target.Upstream = s.upstream
+		}
+		targets = append(targets, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
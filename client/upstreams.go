@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+var (
+	HealthchecksTypes = []string{"http", "tcp", "https"}
+)
+
+type PassiveHealthy struct {
+	Successes    int   `json:"successes"`
+	HttpStatuses []int `json:"http_statuses,omitempty"`
+}
+
+type PassiveUnhealthy struct {
+	HttpFailures int   `json:"http_failures"`
+	HttpStatuses []int `json:"http_statuses,omitempty"`
+	TcpFailures  int   `json:"tcp_failures"`
+	Timeouts     int   `json:"timeouts"`
+}
+
+type HealthChecksPassive struct {
+	Type      string            `json:"type,omitempty"`
+	Healthy   *PassiveHealthy   `json:"healthy,omitempty"`
+	Unhealthy *PassiveUnhealthy `json:"unhealthy,omitempty"`
+}
+
+type ActiveHealthy struct {
+	Successes    int   `json:"successes"`
+	Interval     int   `json:"interval"`
+	HttpStatuses []int `json:"http_statuses,omitempty"`
+}
+
+type ActiveUnhealthy struct {
+	HttpStatuses []int `json:"http_statuses,omitempty"`
+	TcpFailures  int   `json:"tcp_failures"`
+	Timeouts     int   `json:"timeouts"`
+	HttpFailures int   `json:"http_failures"`
+	Interval     int   `json:"interval"`
+}
+
+type HealthChecksActive struct {
+	HttpsVerifyCertificate bool             `json:"https_verify_certificate"`
+	HttpPath               string           `json:"http_path,omitempty"`
+	Timeout                int              `json:"timeout,omitempty"`
+	HttpsSni               *string          `json:"https_sni,omitempty"`
+	Concurrency            int              `json:"concurrency,omitempty"`
+	Type                   string           `json:"type,omitempty"`
+	Healthy                *ActiveHealthy   `json:"healthy,omitempty"`
+	Unhealthy              *ActiveUnhealthy `json:"unhealthy,omitempty"`
+}
+
+type UpstreamHealthChecks struct {
+	Active  *HealthChecksActive  `json:"active,omitempty"`
+	Passive *HealthChecksPassive `json:"passive,omitempty"`
+}
+
+type Upstream struct {
+	ID                      string                `json:"id,omitempty"`
+	Name                    string                `json:"name,omitempty"`
+	Algorithm               string                `json:"algorithm,omitempty"`
+	HashOn                  string                `json:"hash_on"`
+	HashFallback            string                `json:"hash_fallback"`
+	HashOnHeader            string                `json:"hash_on_header,omitempty"`
+	HashFallbackHeader      string                `json:"hash_fallback_header,omitempty"`
+	HashOnCookie            string                `json:"hash_on_cookie,omitempty"`
+	HashOnCookiePath        string                `json:"hash_on_cookie_path,omitempty"`
+	HashOnQueryArg          string                `json:"hash_on_query_arg,omitempty"`
+	HashFallbackOnQueryArg  string                `json:"hash_fallback_query_arg,omitempty"`
+	HashOnUriCapture        string                `json:"hash_on_uri_capture,omitempty"`
+	HashFallbacOnUriCapture string                `json:"hash_fallback_uri_capture,omitempty"`
+	Slots                   int                   `json:"slots,omitempty"`
+	HealthChecks            *UpstreamHealthChecks `json:"healthchecks,omitempty"`
+	Tags                    []string              `json:"tags"`
+	HostHeader              string                `json:"host_header,omitempty"`
+	ClientCertificate       Certificate           `json:"-"`
+	UseSrvName              *bool                 `json:"use_srv_name,omitempty"`
+}
+
+// UpstreamService handles the /upstreams endpoints.
+type UpstreamService struct {
+	client *Client
+}
+
+func (s *UpstreamService) Create(ctx context.Context, upstream *Upstream) (*Upstream, error) {
+	created := new(Upstream)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(upstream).Post("upstreams/"), created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *UpstreamService) Get(ctx context.Context, idOrName string) (*Upstream, error) {
+	upstream := new(Upstream)
+	err := s.client.do(ctx, s.client.newRequest().Path("upstreams/").Get(escape(idOrName)), upstream)
+	if err != nil {
+		return nil, err
+	}
+
+	return upstream, nil
+}
+
+func (s *UpstreamService) Update(ctx context.Context, upstream *Upstream) (*Upstream, error) {
+	updated := new(Upstream)
+	err := s.client.do(ctx, s.client.newRequest().BodyJSON(upstream).Path("upstreams/").Patch(escape(upstream.ID)), updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *UpstreamService) Delete(ctx context.Context, idOrName string) error {
+	return s.client.do(ctx, s.client.newRequest().Path("upstreams/").Delete(escape(idOrName)), nil)
+}
+
+func (s *UpstreamService) List(ctx context.Context, opt *ListOptions) ([]*Upstream, error) {
+	var upstreams []*Upstream
+	err := s.client.list(ctx, "upstreams/", opt, func(data json.RawMessage) error {
+		var page []*Upstream
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		upstreams = append(upstreams, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return upstreams, nil
+}
+
+// Targets returns the service managing the targets of the given upstream
+// (id or name).
+func (s *UpstreamService) Targets(upstream string) *TargetService {
+	return &TargetService{client: s.client, upstream: upstream}
+}
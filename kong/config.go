@@ -1,6 +1,13 @@
 package kong
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/dghubble/sling"
 )
 
@@ -8,8 +15,102 @@ type Config struct {
 	Address  string
 	Username string
 	Password string
+
+	// Workspace is the Kong Enterprise workspace targeted by default.
+	Workspace string
+
+	// AdminToken is sent as Kong-Admin-Token, as expected by Kong Enterprise RBAC.
+	AdminToken string
+	// APIKey is sent in the APIKeyHeader header, for Admin APIs exposed
+	// through a Kong gateway protected by the key-auth plugin.
+	APIKey       string
+	APIKeyHeader string
+	// Headers are static headers added to every request.
+	Headers map[string]string
+
+	// TLS settings of the Admin API connection. Certificates and keys are
+	// PEM-encoded.
+	TLSCACert     string
+	TLSClientCert string
+	TLSClientKey  string
+	TLSServerName string
+	TLSSkipVerify bool
+
+	// Retry policy of idempotent requests and timeout of every attempt.
+	MaxRetries      int
+	RetryBackoffMin time.Duration
+	RetryBackoffMax time.Duration
+	RequestTimeout  time.Duration
+}
+
+func (c *Config) Client() (*client.Client, error) {
+	httpClient, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	s := sling.New().Client(httpClient).Base(c.Address)
+
+	if c.Username != "" || c.Password != "" {
+		s.SetBasicAuth(c.Username, c.Password)
+	}
+
+	for name, value := range c.Headers {
+		s.Set(name, value)
+	}
+
+	if c.AdminToken != "" {
+		s.Set("Kong-Admin-Token", c.AdminToken)
+	}
+
+	if c.APIKey != "" {
+		s.Set(c.APIKeyHeader, c.APIKey)
+	}
+
+	return client.New(s).Workspace(c.Workspace), nil
+}
+
+func (c *Config) httpClient() (*http.Client, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Transport: &client.RetryTransport{
+			Next:       &client.LoggingTransport{Next: transport},
+			MaxRetries: c.MaxRetries,
+			BackoffMin: c.RetryBackoffMin,
+			BackoffMax: c.RetryBackoffMax,
+			Timeout:    c.RequestTimeout,
+		},
+	}, nil
 }
 
-func (c *Config) Client() (*sling.Sling, error) {
-	return sling.New().SetBasicAuth(c.Username, c.Password).Base(c.Address), nil
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         c.TLSServerName,
+		InsecureSkipVerify: c.TLSSkipVerify,
+	}
+
+	if c.TLSCACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.TLSCACert)) {
+			return nil, fmt.Errorf("tls_ca_cert doesn't contain any valid PEM certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.TLSClientCert != "" || c.TLSClientKey != "" {
+		certificate, err := tls.X509KeyPair([]byte(c.TLSClientCert), []byte(c.TLSClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls_client_cert / tls_client_key pair: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
 }
@@ -0,0 +1,167 @@
+package kong
+
+import (
+	"context"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKongConsumer() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKongConsumerRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "username", "custom_id"},
+				Description:  "The id of the consumer to look up.",
+			},
+
+			"username": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "username", "custom_id"},
+				Description:  "The username of the consumer to look up.",
+			},
+
+			"custom_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "username", "custom_id"},
+				Description:  "The custom_id of the consumer to look up.",
+			},
+
+			"tags": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+
+			"include_acl_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to read the ACL groups of the consumer into acl_groups.",
+			},
+
+			"include_credentials": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to read the ids of the consumer credentials into jwt_credential_ids, key_auth_credential_ids and basic_auth_credential_ids.",
+			},
+
+			"acl_groups": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+
+			"jwt_credential_ids": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+
+			"key_auth_credential_ids": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+
+			"basic_auth_credential_ids": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceKongConsumerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	var consumer *client.Consumer
+	var err error
+
+	if customID, ok := d.GetOk("custom_id"); ok {
+		consumer, err = c.Consumers.GetByCustomID(ctx, customID.(string))
+		if err != nil {
+			return errorDiagnostics("error while reading Consumer", err)
+		} else if consumer == nil {
+			return diag.Errorf("Consumer with custom_id %q not found", customID)
+		}
+	} else {
+		idOrUsername := d.Get("id").(string)
+		if idOrUsername == "" {
+			idOrUsername = d.Get("username").(string)
+		}
+
+		consumer, err = c.Consumers.Get(ctx, idOrUsername)
+		if client.IsNotFound(err) {
+			return diag.Errorf("Consumer %q not found", idOrUsername)
+		} else if err != nil {
+			return errorDiagnostics("error while reading Consumer", err)
+		}
+	}
+
+	setConsumerToResourceData(d, consumer)
+
+	if d.Get("include_acl_groups").(bool) {
+		aclGroups, err := c.Consumers.ACLGroups(consumer.ID).List(ctx, nil)
+		if err != nil {
+			return errorDiagnostics("error while listing Consumer ACL groups", err)
+		}
+
+		groups := make([]string, len(aclGroups))
+		for i, aclGroup := range aclGroups {
+			groups[i] = aclGroup.Group
+		}
+		_ = d.Set("acl_groups", groups)
+	}
+
+	if d.Get("include_credentials").(bool) {
+		jwtCredentials, err := c.Consumers.JWTCredentials(consumer.ID).List(ctx, nil)
+		if err != nil {
+			return errorDiagnostics("error while listing Consumer JWT credentials", err)
+		}
+
+		ids := make([]string, len(jwtCredentials))
+		for i, credential := range jwtCredentials {
+			ids[i] = credential.ID
+		}
+		_ = d.Set("jwt_credential_ids", ids)
+
+		keyAuthCredentials, err := c.Consumers.KeyAuthCredentials(consumer.ID).List(ctx, nil)
+		if err != nil {
+			return errorDiagnostics("error while listing Consumer key-auth credentials", err)
+		}
+
+		ids = make([]string, len(keyAuthCredentials))
+		for i, credential := range keyAuthCredentials {
+			ids[i] = credential.ID
+		}
+		_ = d.Set("key_auth_credential_ids", ids)
+
+		basicAuthCredentials, err := c.Consumers.BasicAuthCredentials(consumer.ID).List(ctx, nil)
+		if err != nil {
+			return errorDiagnostics("error while listing Consumer basic-auth credentials", err)
+		}
+
+		ids = make([]string, len(basicAuthCredentials))
+		for i, credential := range basicAuthCredentials {
+			ids[i] = credential.ID
+		}
+		_ = d.Set("basic_auth_credential_ids", ids)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package kong
+
+import (
+	"context"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKongConsumers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKongConsumersRead,
+		Schema: listDataSourceSchema("consumers", &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"username": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"custom_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"tags": {
+					Type:     schema.TypeList,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Computed: true,
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceKongConsumersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	consumers, err := c.Consumers.List(ctx, listOptionsFromResourceData(d, c.WorkspaceName()))
+	if err != nil {
+		return errorDiagnostics("error while listing Consumers", err)
+	}
+
+	flattened := make([]interface{}, len(consumers))
+	for i, consumer := range consumers {
+		flattened[i] = flattenConsumer(consumer)
+	}
+	_ = d.Set("consumers", flattened)
+
+	return nil
+}
+
+func flattenConsumer(consumer *client.Consumer) map[string]interface{} {
+	return map[string]interface{}{
+		"id":        consumer.ID,
+		"username":  consumer.Username,
+		"custom_id": consumer.CustomID,
+		"tags":      consumer.Tags,
+	}
+}
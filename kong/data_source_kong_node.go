@@ -0,0 +1,234 @@
+package kong
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKongNode() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKongNodeRead,
+
+		Schema: map[string]*schema.Schema{
+			"node_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"hostname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version reported by Kong, e.g. 3.4.3.5 for Kong Enterprise.",
+			},
+
+			"version_major": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"version_minor": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"version_patch": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"edition": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Either community or enterprise.",
+			},
+
+			"lua_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"database": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The database of the node: postgres, cassandra or off for DB-less nodes.",
+			},
+
+			"router_flavor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The router of the node: traditional, traditional_compatible or expressions. Empty before Kong 3.0.",
+			},
+
+			"plugins_available": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "The plugins installed on the node.",
+			},
+
+			"plugins_enabled": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "The plugins configured somewhere in the cluster.",
+			},
+
+			"database_reachable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"connections_active": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"connections_reading": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"connections_writing": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"connections_waiting": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"connections_accepted": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"connections_handled": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"total_requests": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"workers_lua_vms": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The memory allocated by the Lua VM of every worker.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pid": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"http_allocated_gc": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"lua_shared_dicts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The memory usage of every shared dictionary.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"allocated_slabs": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"capacity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKongNodeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).client
+
+	info, err := c.Info(ctx)
+	if err != nil {
+		return errorDiagnostics("error while reading Kong node information", err)
+	}
+
+	version, err := info.ParsedVersion()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	status, err := c.Status(ctx)
+	if err != nil {
+		return errorDiagnostics("error while reading Kong node status", err)
+	}
+
+	d.SetId(info.NodeID)
+	_ = d.Set("node_id", info.NodeID)
+	_ = d.Set("hostname", info.Hostname)
+	_ = d.Set("version", info.Version)
+	_ = d.Set("version_major", version.Major)
+	_ = d.Set("version_minor", version.Minor)
+	_ = d.Set("version_patch", version.Patch)
+	_ = d.Set("edition", info.Edition())
+	_ = d.Set("lua_version", info.LuaVersion)
+	_ = d.Set("database", info.Configuration.Database)
+	_ = d.Set("router_flavor", info.Configuration.RouterFlavor)
+	_ = d.Set("plugins_available", info.Plugins.Available())
+	_ = d.Set("plugins_enabled", info.Plugins.EnabledInCluster)
+
+	_ = d.Set("database_reachable", status.Database.Reachable)
+	_ = d.Set("connections_active", status.Server.ConnectionsActive)
+	_ = d.Set("connections_reading", status.Server.ConnectionsReading)
+	_ = d.Set("connections_writing", status.Server.ConnectionsWriting)
+	_ = d.Set("connections_waiting", status.Server.ConnectionsWaiting)
+	_ = d.Set("connections_accepted", status.Server.ConnectionsAccepted)
+	_ = d.Set("connections_handled", status.Server.ConnectionsHandled)
+	_ = d.Set("total_requests", status.Server.TotalRequests)
+
+	workers := make([]interface{}, len(status.Memory.WorkersLuaVMs))
+	for i, worker := range status.Memory.WorkersLuaVMs {
+		workers[i] = map[string]interface{}{
+			"pid":               worker.PID,
+			"http_allocated_gc": worker.HTTPAllocatedGC,
+		}
+	}
+	_ = d.Set("workers_lua_vms", workers)
+
+	names := make([]string, 0, len(status.Memory.LuaSharedDicts))
+	for name := range status.Memory.LuaSharedDicts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dicts := make([]interface{}, len(names))
+	for i, name := range names {
+		dicts[i] = map[string]interface{}{
+			"name":            name,
+			"allocated_slabs": status.Memory.LuaSharedDicts[name].AllocatedSlabs,
+			"capacity":        status.Memory.LuaSharedDicts[name].Capacity,
+		}
+	}
+	_ = d.Set("lua_shared_dicts", dicts)
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceKongRouteMatch simulates the traditional router against the
+// routes in Kong and the kong_route resources planned before the data source
+// is read.
+func dataSourceKongRouteMatch() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKongRouteMatchRead,
+
+		Schema: mergeSchemas(listDataSourceSchema("candidates", &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"service": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"matched_path": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}), map[string]*schema.Schema{
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "http",
+				ValidateFunc: validation.StringInSlice([]string{"http", "https", "grpc", "grpcs"}, false),
+				Description:  "The protocol of the request: http (default), https, grpc or grpcs.",
+			},
+
+			"method": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "GET",
+				Description: "The method of the request. Defaults to GET.",
+			},
+
+			"host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Host header of the request, optionally followed by a port.",
+			},
+
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "/",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/`), "must start with /"),
+				Description:  "The path of the request. Defaults to /.",
+			},
+
+			"headers": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "The headers of the request.",
+			},
+
+			"sni": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The SNI of the TLS handshake of https and grpcs requests.",
+			},
+
+			"matched": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether a route matches the request.",
+			},
+
+			"route_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"route_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"service": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the Service of the selected route.",
+			},
+
+			"matched_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The path of the selected route matching the request, empty when the route has no paths.",
+			},
+
+			"redirect_status_code": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The status code Kong answers with instead of proxying the request, when the selected route doesn't accept its protocol but the secure one. 0 otherwise.",
+			},
+
+			"reason": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Why the selected route wins over the other candidates.",
+			},
+		}),
+	}
+}
+
+func dataSourceKongRouteMatchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	info, err := c.Info(ctx)
+	if err != nil {
+		return errorDiagnostics("error while reading Kong node information", err)
+	}
+
+	version, err := info.ParsedVersion()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	if info.Configuration.RouterFlavor == "expressions" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Kong %s runs the expressions router", info.Version),
+			Detail:   "Only the routes set with traditional matchers are simulated, the routes set with an expression are left out.",
+		})
+	}
+
+	opt := listOptionsFromResourceData(d, c.WorkspaceName())
+
+	listed, err := c.Routes.List(ctx, opt)
+	if err != nil {
+		return errorDiagnostics("error while listing Routes", err)
+	}
+
+	var routes []*client.Route
+	for _, route := range meta.(*providerMeta).routes.withPlanned(c, listed) {
+		if opt.Match(route.Tags) {
+			routes = append(routes, route)
+		}
+	}
+
+	request := &routeRequest{
+		Protocol: d.Get("protocol").(string),
+		Method:   d.Get("method").(string),
+		Host:     d.Get("host").(string),
+		Path:     d.Get("path").(string),
+		SNI:      d.Get("sni").(string),
+		Headers:  map[string]string{},
+	}
+	for name, value := range d.Get("headers").(map[string]interface{}) {
+		request.Headers[name] = value.(string)
+	}
+
+	matches := matchRouteRequest(routes, version.AtLeast(client.Version{Major: 3}), request)
+
+	d.SetId(strconv.Itoa(schema.HashString(routeRequestString(request))))
+
+	candidates := make([]interface{}, len(matches))
+	for i, match := range matches {
+		candidates[i] = map[string]interface{}{
+			"id":           match.route.ID,
+			"name":         match.route.Name,
+			"service":      match.route.Service.ID,
+			"matched_path": match.path,
+		}
+	}
+	_ = d.Set("candidates", candidates)
+
+	_ = d.Set("matched", len(matches) > 0)
+	if len(matches) == 0 {
+		_ = d.Set("route_id", "")
+		_ = d.Set("route_name", "")
+		_ = d.Set("service", "")
+		_ = d.Set("matched_path", "")
+		_ = d.Set("redirect_status_code", 0)
+		_ = d.Set("reason", "no route matches the request")
+		return diags
+	}
+
+	selected := matches[0]
+	_ = d.Set("route_id", selected.route.ID)
+	_ = d.Set("route_name", selected.route.Name)
+	_ = d.Set("service", selected.route.Service.ID)
+	_ = d.Set("matched_path", selected.path)
+
+	reason := fmt.Sprintf("route %s is the only route matching the request", selected.name())
+	if len(matches) > 1 {
+		if first, why := compareRouteMatchers(selected.routeMatcher, matches[1].routeMatcher); first {
+			reason = fmt.Sprintf("route %s is evaluated before route %s, which also matches the request, as %s", selected.name(), matches[1].name(), why)
+		} else {
+			reason = fmt.Sprintf("route %s and route %s both match the request and tie on every criterion of the router, which may select either", selected.name(), matches[1].name())
+		}
+	}
+
+	redirect := 0
+	if selected.redirect {
+		redirect = selected.route.HttpsRedirectStatusCode
+		if request.Protocol == "grpc" {
+			// https_redirect_status_code only applies to HTTP requests.
+			redirect = 426
+		}
+		reason += fmt.Sprintf(", and Kong answers %s requests with a %d since the route only accepts %s", request.Protocol, redirect, strings.Join(selected.route.Protocols, ", "))
+	}
+	_ = d.Set("redirect_status_code", redirect)
+	_ = d.Set("reason", reason)
+
+	return diags
+}
+
+// routeRequestString returns a canonical representation of the request.
+func routeRequestString(request *routeRequest) string {
+	names := make([]string, 0, len(request.Headers))
+	for name := range request.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := []string{request.Protocol, request.Method, request.Host, request.Path, request.SNI}
+	for _, name := range names {
+		parts = append(parts, name+"="+request.Headers[name])
+	}
+
+	return strings.Join(parts, "|")
+}
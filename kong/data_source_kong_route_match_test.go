@@ -0,0 +1,74 @@
+package kong
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceKongRouteMatchRead(t *testing.T) {
+	meta := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = io.WriteString(w, `{"version":"3.4.0","configuration":{"router_flavor":"traditional_compatible"}}`)
+		case "/routes/", "/routes":
+			_, _ = io.WriteString(w, `{"data":[
+				{"id":"catch-all","name":"catch-all","protocols":["http","https"],"paths":["/"],"created_at":1,"service":{"id":"s1"}},
+				{"id":"orders","name":"orders","protocols":["http","https"],"paths":["/orders"],"created_at":2,"service":{"id":"s1"}},
+				{"id":"orders-copy","name":"orders-copy","protocols":["http","https"],"paths":["/orders"],"created_at":2,"service":{"id":"s1"}}
+			],"next":null}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}, nil)
+
+	// A route planned for creation, not in Kong yet.
+	if _, err := testPlan(resourceKongRoute(), meta, "", nil, map[string]cty.Value{
+		"name":      cty.StringVal("users"),
+		"protocols": cty.ListVal([]cty.Value{cty.StringVal("http"), cty.StringVal("https")}),
+		"paths":     cty.ListVal([]cty.Value{cty.StringVal("/users")}),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path   string
+		route  string
+		reason string
+	}{
+		{"/users/42", "users", "route users is evaluated before route catch-all, which also matches the request, as its longest path has 6 characters against 1"},
+		{"/orders/42", "orders", "route orders and route orders-copy both match the request and tie on every criterion of the router, which may select either"},
+		{"/items", "catch-all", "route catch-all is the only route matching the request"},
+	}
+
+	for _, test := range tests {
+		var ids []string
+		for read := 0; read < 2; read++ {
+			d := schema.TestResourceDataRaw(t, dataSourceKongRouteMatch().Schema, map[string]interface{}{"path": test.path})
+			if read > 0 {
+				d.SetId("previous")
+			}
+
+			if diags := dataSourceKongRouteMatchRead(context.Background(), d, meta); diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			if route := d.Get("route_name").(string); route != test.route {
+				t.Errorf("%s selects route %s, want %s", test.path, route, test.route)
+			}
+			if reason := d.Get("reason").(string); !strings.HasPrefix(reason, test.reason) {
+				t.Errorf("%s selected as %q, want %q", test.path, reason, test.reason)
+			}
+			ids = append(ids, d.Id())
+		}
+
+		if ids[0] != ids[1] {
+			t.Errorf("%s read with ids %v, want the same id", test.path, ids)
+		}
+	}
+}
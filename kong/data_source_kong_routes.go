@@ -0,0 +1,202 @@
+package kong
+
+import (
+	"context"
+	"sort"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKongRoutes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKongRoutesRead,
+		Schema: listDataSourceSchema("routes", &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"protocols": {
+					Type:     schema.TypeList,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Computed: true,
+				},
+				"methods": {
+					Type:     schema.TypeList,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Computed: true,
+				},
+				"hosts": {
+					Type:     schema.TypeList,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Computed: true,
+				},
+				"paths": {
+					Type:     schema.TypeList,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Computed: true,
+				},
+				"header": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"values": {
+								Type:     schema.TypeList,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+								Computed: true,
+							},
+						},
+					},
+				},
+				"https_redirect_status_code": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"regex_priority": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"strip_path": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"path_handling": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"preserve_host": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"request_buffering": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"response_buffering": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"snis": {
+					Type:     schema.TypeList,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Computed: true,
+				},
+				"source":      routeEndpointsDataSourceSchema(),
+				"destination": routeEndpointsDataSourceSchema(),
+				"expression": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"priority": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"tags": {
+					Type:     schema.TypeList,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+					Computed: true,
+				},
+				"service": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceKongRoutesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	routes, err := c.Routes.List(ctx, listOptionsFromResourceData(d, c.WorkspaceName()))
+	if err != nil {
+		return errorDiagnostics("error while listing Routes", err)
+	}
+
+	flattened := make([]interface{}, len(routes))
+	for i, route := range routes {
+		flattened[i] = flattenRoute(route)
+	}
+	_ = d.Set("routes", flattened)
+
+	return nil
+}
+
+func flattenRoute(route *client.Route) map[string]interface{} {
+	names := make([]string, 0, len(route.Headers))
+	for name := range route.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := make([]interface{}, len(names))
+	for i, name := range names {
+		headers[i] = map[string]interface{}{
+			"name":   name,
+			"values": route.Headers[name],
+		}
+	}
+
+	flattened := map[string]interface{}{
+		"id":                         route.ID,
+		"name":                       route.Name,
+		"protocols":                  route.Protocols,
+		"methods":                    route.Methods,
+		"hosts":                      route.Hosts,
+		"paths":                      route.Paths,
+		"header":                     headers,
+		"https_redirect_status_code": route.HttpsRedirectStatusCode,
+		"regex_priority":             route.RegexPriority,
+		"strip_path":                 route.StripPath,
+		"path_handling":              route.PathHandling,
+		"preserve_host":              route.PreserveHost,
+		"snis":                       route.SNIs,
+		"source":                     flattenRouteEndpoints(route.Sources),
+		"destination":                flattenRouteEndpoints(route.Destinations),
+		"expression":                 route.Expression,
+		"tags":                       route.Tags,
+		"service":                    route.Service.ID,
+	}
+
+	if route.Priority != nil {
+		flattened["priority"] = *route.Priority
+	}
+	if route.RequestBuffering != nil {
+		flattened["request_buffering"] = *route.RequestBuffering
+	}
+	if route.ResponseBuffering != nil {
+		flattened["response_buffering"] = *route.ResponseBuffering
+	}
+
+	return flattened
+}
+
+func routeEndpointsDataSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ip": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"port": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,153 @@
+package kong
+
+import (
+	"context"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/WeKnowSports/terraform-provider-kong/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKongService() *schema.Resource {
+	attributes := serviceDataSourceAttributes()
+
+	attributes["workspace"] = workspaceSchema()
+	attributes["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name", "filter_tags"},
+		Description:  "The id of the Service to look up.",
+	}
+	attributes["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name", "filter_tags"},
+		Description:  "The name of the Service to look up.",
+	}
+	attributes["filter_tags"] = &schema.Schema{
+		Type:         schema.TypeList,
+		Elem:         &schema.Schema{Type: schema.TypeString},
+		Optional:     true,
+		MinItems:     1,
+		ExactlyOneOf: []string{"id", "name", "filter_tags"},
+		Description:  "Look the Service up by tags instead. Exactly one Service must carry all of them.",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceKongServiceRead,
+		Schema:      attributes,
+	}
+}
+
+// serviceDataSourceAttributes returns the computed attributes exposing a
+// Service in data sources.
+func serviceDataSourceAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"protocol": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"host": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"port": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"path": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"retries": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"connect_timeout": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"write_timeout": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"read_timeout": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"tags": {
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+		},
+		"client_certificate": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"tls_verify": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"tls_verify_depth": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"ca_certificates": {
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+	}
+}
+
+func dataSourceKongServiceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	var service *client.Service
+
+	if tags, ok := d.GetOk("filter_tags"); ok {
+		services, err := c.Services.List(ctx, &client.ListOptions{
+			Tags: helper.ConvertInterfaceArrToStrings(tags.([]interface{})),
+		})
+		if err != nil {
+			return errorDiagnostics("error while listing Services", err)
+		}
+
+		if len(services) != 1 {
+			return diag.Errorf("the tags must match exactly one Service, they match %d", len(services))
+		}
+		service = services[0]
+	} else {
+		idOrName := d.Get("id").(string)
+		if idOrName == "" {
+			idOrName = d.Get("name").(string)
+		}
+
+		var err error
+		service, err = c.Services.Get(ctx, idOrName)
+		if client.IsNotFound(err) {
+			return diag.Errorf("Service %q not found", idOrName)
+		} else if err != nil {
+			return errorDiagnostics("error while reading Service", err)
+		}
+	}
+
+	setServiceToResourceData(d, service)
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package kong
+
+import (
+	"context"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKongServices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKongServicesRead,
+		Schema: listDataSourceSchema("services", &schema.Resource{
+			Schema: serviceDataSourceAttributes(),
+		}),
+	}
+}
+
+func dataSourceKongServicesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	services, err := c.Services.List(ctx, listOptionsFromResourceData(d, c.WorkspaceName()))
+	if err != nil {
+		return errorDiagnostics("error while listing Services", err)
+	}
+
+	flattened := make([]interface{}, len(services))
+	for i, service := range services {
+		flattened[i] = flattenService(service)
+	}
+	_ = d.Set("services", flattened)
+
+	return nil
+}
+
+func flattenService(service *client.Service) map[string]interface{} {
+	flattened := map[string]interface{}{
+		"id":              service.ID,
+		"name":            service.Name,
+		"protocol":        service.Protocol,
+		"host":            service.Host,
+		"port":            service.Port,
+		"path":            service.Path,
+		"retries":         service.Retries,
+		"connect_timeout": service.ConnectTimeout,
+		"write_timeout":   service.WriteTimeout,
+		"read_timeout":    service.ReadTimeout,
+		"tags":            service.Tags,
+		"ca_certificates": service.CACertificates,
+	}
+
+	if service.ClientCertificate != nil {
+		flattened["client_certificate"] = service.ClientCertificate.ID
+	}
+	if service.Enabled != nil {
+		flattened["enabled"] = *service.Enabled
+	}
+	if service.TlsVerify != nil {
+		flattened["tls_verify"] = *service.TlsVerify
+	}
+	if service.TlsVerifyDepth != nil {
+		flattened["tls_verify_depth"] = *service.TlsVerifyDepth
+	}
+
+	return flattened
+}
@@ -0,0 +1,136 @@
+package kong
+
+import (
+	"context"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKongUpstreamHealth() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKongUpstreamHealthRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
+			"upstream": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id or the name of the upstream.",
+			},
+
+			"health": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The overall health of the upstream: HEALTHY, UNHEALTHY or HEALTHCHECKS_OFF.",
+			},
+
+			"targets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The targets of the upstream, as seen by the balancer of the node answering the Admin API.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"target": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"weight": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"health": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "HEALTHY, UNHEALTHY, DNS_ERROR or HEALTHCHECKS_OFF.",
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+						"addresses": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The addresses the target resolves to.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ip": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"port": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"weight": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"health": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKongUpstreamHealthRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	upstream := d.Get("upstream").(string)
+
+	balancerHealth, err := c.Upstreams.BalancerHealth(ctx, upstream)
+	if err != nil {
+		return errorDiagnostics("error while reading upstream health", err)
+	}
+
+	targets, err := c.Upstreams.Health(ctx, upstream)
+	if err != nil {
+		return errorDiagnostics("error while reading upstream targets health", err)
+	}
+
+	d.SetId(balancerHealth.ID)
+	_ = d.Set("health", balancerHealth.Health)
+	_ = d.Set("targets", flattenTargetsHealth(targets))
+
+	return nil
+}
+
+func flattenTargetsHealth(targets []*client.TargetHealth) []interface{} {
+	flattened := make([]interface{}, len(targets))
+	for i, target := range targets {
+		addresses := make([]interface{}, len(target.Data.Addresses))
+		for j, address := range target.Data.Addresses {
+			addresses[j] = map[string]interface{}{
+				"ip":     address.IP,
+				"port":   address.Port,
+				"weight": address.Weight,
+				"health": address.Health,
+			}
+		}
+
+		flattened[i] = map[string]interface{}{
+			"id":        target.ID,
+			"target":    target.Target,
+			"weight":    target.Weight,
+			"health":    target.Health,
+			"tags":      target.Tags,
+			"addresses": addresses,
+		}
+	}
+
+	return flattened
+}
@@ -0,0 +1,59 @@
+package kong
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/WeKnowSports/terraform-provider-kong/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	tagsMatchAll = "all"
+	tagsMatchAny = "any"
+)
+
+// listDataSourceSchema returns the schema of a data source listing entities,
+// filtered by the tags and tags_match arguments, into the attribute.
+func listDataSourceSchema(attribute string, elem *schema.Resource) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"workspace": workspaceSchema(),
+
+		"tags": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "Only list the entities carrying these tags. Every entity is listed when empty.",
+		},
+
+		"tags_match": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      tagsMatchAll,
+			ValidateFunc: validation.StringInSlice([]string{tagsMatchAll, tagsMatchAny}, false),
+			Description:  "Whether the entities must carry all of the tags or any of them. One of all (default) or any.",
+		},
+
+		attribute: {
+			Type:     schema.TypeList,
+			Elem:     elem,
+			Computed: true,
+		},
+	}
+}
+
+// listOptionsFromResourceData returns the list options of a data source using
+// listDataSourceSchema, and sets its id from them.
+func listOptionsFromResourceData(d *schema.ResourceData, workspace string) *client.ListOptions {
+	opt := &client.ListOptions{
+		Tags:        helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		MatchAnyTag: d.Get("tags_match").(string) == tagsMatchAny,
+	}
+
+	id := workspace + "|" + d.Get("tags_match").(string) + "|" + strings.Join(opt.Tags, ",")
+	d.SetId(strconv.Itoa(schema.HashString(id)))
+
+	return opt
+}
@@ -0,0 +1,183 @@
+package kong
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// kongFieldAttributes maps Kong field names to the attribute holding them
+// where the two differ.
+var kongFieldAttributes = map[string]string{
+	"headers":      "header",
+	"config":       "config_json",
+	"sources":      "source",
+	"destinations": "destination",
+}
+
+// listAttributes are the attributes whose Kong field errors can be reported
+// against a single element. Sets can't be indexed, and everything else is a
+// scalar or carries its nested errors in the detail.
+var listAttributes = map[string]bool{
+	"protocols":       true,
+	"methods":         true,
+	"hosts":           true,
+	"paths":           true,
+	"snis":            true,
+	"tags":            true,
+	"ca_certificates": true,
+}
+
+// errorDiagnostics turns err into diagnostics. When Kong rejected the entity
+// with field errors, every failing field gets its own diagnostic pointing at
+// the matching attribute, otherwise the error is reported as a whole.
+func errorDiagnostics(summary string, err error) diag.Diagnostics {
+	var kongError *client.Error
+	if !errors.As(err, &kongError) || len(kongError.Fields) == 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   err.Error(),
+		}}
+	}
+
+	if kongError.Name != "" {
+		summary += ": " + kongError.Name
+	}
+
+	var diags diag.Diagnostics
+
+	fields := make([]string, 0, len(kongError.Fields))
+	for field := range kongError.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		value := kongError.Fields[field]
+
+		if field == "@entity" {
+			var messages []string
+			if values, ok := value.([]interface{}); ok {
+				for _, v := range values {
+					messages = append(messages, flattenFieldErrors("", v)...)
+				}
+			} else {
+				messages = flattenFieldErrors("", value)
+			}
+
+			for _, message := range messages {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  summary,
+					Detail:   message,
+				})
+			}
+			continue
+		}
+
+		attribute := field
+		if renamed, ok := kongFieldAttributes[field]; ok {
+			attribute = renamed
+		}
+
+		if values, ok := value.([]interface{}); ok && listAttributes[attribute] {
+			for i, v := range values {
+				for _, message := range flattenFieldErrors(fmt.Sprintf("%s.%d", field, i+1), v) {
+					diags = append(diags, diag.Diagnostic{
+						Severity:      diag.Error,
+						Summary:       summary,
+						Detail:        message,
+						AttributePath: cty.GetAttrPath(attribute).IndexInt(i),
+					})
+				}
+			}
+			continue
+		}
+
+		for _, message := range flattenFieldErrors(field, value) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       summary,
+				Detail:        message,
+				AttributePath: cty.GetAttrPath(attribute),
+			})
+		}
+	}
+
+	return diags
+}
+
+// diagnosticsError folds diagnostics into a single error, for the places like
+// CustomizeDiff which can't return diagnostics. The error points at the
+// attribute the diagnostics share, if any.
+func diagnosticsError(diags diag.Diagnostics) error {
+	if len(diags) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(diags))
+	path := diags[0].AttributePath
+	for i, d := range diags {
+		messages[i] = d.Detail
+		if !d.AttributePath.Equals(path) {
+			path = nil
+		}
+	}
+
+	err := fmt.Errorf("%s:\n  %s", diags[0].Summary, strings.Join(messages, "\n  "))
+	if len(path) > 0 {
+		return path.NewError(err)
+	}
+
+	return err
+}
+
+// flattenFieldErrors renders a (possibly nested) Kong field error as one
+// "field: message" line per failure. Kong numbers list elements from 1.
+func flattenFieldErrors(field string, value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if field == "" {
+			return []string{v}
+		}
+		return []string{field + ": " + v}
+	case []interface{}:
+		var messages []string
+		for i, item := range v {
+			messages = append(messages, flattenFieldErrors(joinField(field, fmt.Sprint(i+1)), item)...)
+		}
+		return messages
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var messages []string
+		for _, key := range keys {
+			messages = append(messages, flattenFieldErrors(joinField(field, key), v[key])...)
+		}
+		return messages
+	default:
+		return flattenFieldErrors(field, fmt.Sprint(v))
+	}
+}
+
+func joinField(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, ".")
+}
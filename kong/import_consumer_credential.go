@@ -1,16 +1,22 @@
 package kong
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func ImportConsumerCredential(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func ImportConsumerCredential(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.Split(d.Id(), "/")
+	if len(parts) == 3 {
+		d.Set("workspace", parts[0])
+		parts = parts[1:]
+	}
+
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("expected a string in the format \"<consumer_id>/<credential_id>\" to import")
+		return nil, fmt.Errorf("expected a string in the format \"[<workspace>/]<consumer_id>/<credential_id>\" to import")
 	}
 
 	d.Set("consumer", parts[0])
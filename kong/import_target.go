@@ -0,0 +1,27 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ImportTarget imports a target from "[<workspace>/]<upstream>/<target>",
+// where the target is either its id or its address.
+func ImportTarget(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) == 3 {
+		d.Set("workspace", parts[0])
+		parts = parts[1:]
+	}
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected a string in the format \"[<workspace>/]<upstream>/<target>\" to import")
+	}
+
+	d.Set("upstream", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
@@ -1,28 +1,23 @@
 package kong
 
 import (
-	"fmt"
-	"net/http"
+	"context"
 
-	"github.com/dghubble/sling"
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-type CACertificate struct {
-	ID         string   `json:"id,omitempty"`
-	Cert       string   `json:"cert,omitempty"`
-	CertDigest string   `json:"cert_digest,omitempty"`
-	Tags       []string `json:"tags"`
-}
-
 func resourceKongCACertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongCACertificateCreate,
-		Read:   resourceKongCACertificateRead,
-		Update: resourceKongCACertificateUpdate,
-		Delete: resourceKongCACertificateDelete,
+		CreateContext: resourceKongCACertificateCreate,
+		ReadContext:   resourceKongCACertificateRead,
+		UpdateContext: resourceKongCACertificateUpdate,
+		DeleteContext: resourceKongCACertificateDelete,
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
 			"cert": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -46,20 +41,14 @@ func resourceKongCACertificate() *schema.Resource {
 	}
 }
 
-func resourceKongCACertificateCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCACertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	caCertificate := getCACertificateFromResourceData(d)
 
-	createdCACertificate := getCACertificateFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(caCertificate).Post("ca_certificates/").ReceiveSuccess(createdCACertificate)
-	if error != nil {
-		return fmt.Errorf("error while creating caCertificate")
-	}
-
-	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+	createdCACertificate, err := c.CACertificates.Create(ctx, caCertificate)
+	if err != nil {
+		return errorDiagnostics("error while creating caCertificate", err)
 	}
 
 	setCACertificateToResourceData(d, createdCACertificate)
@@ -67,21 +56,15 @@ func resourceKongCACertificateCreate(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func resourceKongCACertificateRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
-
-	caCertificate := getCACertificateFromResourceData(d)
+func resourceKongCACertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	response, error := sling.New().Path("ca_certificates/").Get(caCertificate.ID).ReceiveSuccess(caCertificate)
-	if error != nil {
-		return fmt.Errorf("error while updating caCertificate")
-	}
-
-	if response.StatusCode == http.StatusNotFound {
+	caCertificate, err := c.CACertificates.Get(ctx, d.Id())
+	if client.IsNotFound(err) {
 		d.SetId("")
 		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	} else if err != nil {
+		return errorDiagnostics("error while reading caCertificate", err)
 	}
 
 	setCACertificateToResourceData(d, caCertificate)
@@ -89,20 +72,14 @@ func resourceKongCACertificateRead(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceKongCACertificateUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCACertificateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	caCertificate := getCACertificateFromResourceData(d)
 
-	updatedCACertificate := getCACertificateFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(caCertificate).Path("ca_certificates/").Patch(caCertificate.ID).ReceiveSuccess(updatedCACertificate)
-	if error != nil {
-		return fmt.Errorf("error while updating caCertificate")
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	updatedCACertificate, err := c.CACertificates.Update(ctx, caCertificate)
+	if err != nil {
+		return errorDiagnostics("error while updating caCertificate", err)
 	}
 
 	setCACertificateToResourceData(d, updatedCACertificate)
@@ -110,25 +87,19 @@ func resourceKongCACertificateUpdate(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func resourceKongCACertificateDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
-
-	caCertificate := getCACertificateFromResourceData(d)
-
-	response, error := sling.New().Path("ca_certificates/").Delete(caCertificate.ID).ReceiveSuccess(nil)
-	if error != nil {
-		return fmt.Errorf("error while deleting caCertificate")
-	}
+func resourceKongCACertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	err := c.CACertificates.Delete(ctx, d.Id())
+	if err != nil {
+		return errorDiagnostics("error while deleting caCertificate", err)
 	}
 
 	return nil
 }
 
-func getCACertificateFromResourceData(d *schema.ResourceData) *CACertificate {
-	caCertificate := &CACertificate{
+func getCACertificateFromResourceData(d *schema.ResourceData) *client.CACertificate {
+	caCertificate := &client.CACertificate{
 		ID:         d.Id(),
 		Cert:       d.Get("cert").(string),
 		CertDigest: d.Get("cert_digest").(string),
@@ -137,7 +108,7 @@ func getCACertificateFromResourceData(d *schema.ResourceData) *CACertificate {
 	return caCertificate
 }
 
-func setCACertificateToResourceData(d *schema.ResourceData, caCertificate *CACertificate) {
+func setCACertificateToResourceData(d *schema.ResourceData, caCertificate *client.CACertificate) {
 	d.SetId(caCertificate.ID)
 	d.Set("cert", caCertificate.Cert)
 	d.Set("cert_digest", caCertificate.CertDigest)
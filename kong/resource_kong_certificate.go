@@ -1,31 +1,24 @@
 package kong
 
 import (
-	"fmt"
-	"net/http"
+	"context"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-type Certificate struct {
-	ID      string   `json:"id,omitempty"`
-	Cert    string   `json:"cert,omitempty"`
-	Key     string   `json:"key,omitempty"`
-	CertAlt string   `json:"cert_alt,omitempty"`
-	KeyAlt  string   `json:"key_alt,omitempty"`
-	Tags    []string `json:"tags"`
-}
-
 func resourceKongCertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongCertificateCreate,
-		Read:   resourceKongCertificateRead,
-		Update: resourceKongCertificateUpdate,
-		Delete: resourceKongCertificateDelete,
+		CreateContext: resourceKongCertificateCreate,
+		ReadContext:   resourceKongCertificateRead,
+		UpdateContext: resourceKongCertificateUpdate,
+		DeleteContext: resourceKongCertificateDelete,
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
 			"cert": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -62,20 +55,14 @@ func resourceKongCertificate() *schema.Resource {
 	}
 }
 
-func resourceKongCertificateCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	certificate := getCertificateFromResourceData(d)
 
-	createdCertificate := getCertificateFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(certificate).Post("certificates/").ReceiveSuccess(createdCertificate)
-	if error != nil {
-		return fmt.Errorf("error while creating certificate")
-	}
-
-	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+	createdCertificate, err := c.Certificates.Create(ctx, certificate)
+	if err != nil {
+		return errorDiagnostics("error while creating certificate", err)
 	}
 
 	setCertificateToResourceData(d, createdCertificate)
@@ -83,21 +70,15 @@ func resourceKongCertificateCreate(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceKongCertificateRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
-
-	certificate := getCertificateFromResourceData(d)
+func resourceKongCertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	response, error := sling.New().Path("certificates/").Get(certificate.ID).ReceiveSuccess(certificate)
-	if error != nil {
-		return fmt.Errorf("error while updating certificate")
-	}
-
-	if response.StatusCode == http.StatusNotFound {
+	certificate, err := c.Certificates.Get(ctx, d.Id())
+	if client.IsNotFound(err) {
 		d.SetId("")
 		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	} else if err != nil {
+		return errorDiagnostics("error while reading certificate", err)
 	}
 
 	setCertificateToResourceData(d, certificate)
@@ -105,20 +86,14 @@ func resourceKongCertificateRead(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceKongCertificateUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongCertificateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	certificate := getCertificateFromResourceData(d)
 
-	updatedCertificate := getCertificateFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(certificate).Path("certificates/").Patch(certificate.ID).ReceiveSuccess(updatedCertificate)
-	if error != nil {
-		return fmt.Errorf("error while updating certificate")
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	updatedCertificate, err := c.Certificates.Update(ctx, certificate)
+	if err != nil {
+		return errorDiagnostics("error while updating certificate", err)
 	}
 
 	setCertificateToResourceData(d, updatedCertificate)
@@ -126,25 +101,19 @@ func resourceKongCertificateUpdate(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceKongCertificateDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
-
-	certificate := getCertificateFromResourceData(d)
-
-	response, error := sling.New().Path("certificates/").Delete(certificate.ID).ReceiveSuccess(nil)
-	if error != nil {
-		return fmt.Errorf("error while deleting certificate")
-	}
+func resourceKongCertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	err := c.Certificates.Delete(ctx, d.Id())
+	if err != nil {
+		return errorDiagnostics("error while deleting certificate", err)
 	}
 
 	return nil
 }
 
-func getCertificateFromResourceData(d *schema.ResourceData) *Certificate {
-	certificate := &Certificate{
+func getCertificateFromResourceData(d *schema.ResourceData) *client.Certificate {
+	certificate := &client.Certificate{
 		ID:      d.Id(),
 		Cert:    d.Get("cert").(string),
 		Key:     d.Get("key").(string),
@@ -156,7 +125,7 @@ func getCertificateFromResourceData(d *schema.ResourceData) *Certificate {
 	return certificate
 }
 
-func setCertificateToResourceData(d *schema.ResourceData, certificate *Certificate) {
+func setCertificateToResourceData(d *schema.ResourceData, certificate *client.Certificate) {
 	d.SetId(certificate.ID)
 	d.Set("cert", certificate.Cert)
 	d.Set("key", certificate.Key)
@@ -1,33 +1,28 @@
 package kong
 
 import (
-	"fmt"
-	"net/http"
+	"context"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-type Consumer struct {
-	ID       string   `json:"id,omitempty"`
-	Username string   `json:"username,omitempty"`
-	CustomID string   `json:"custom_id,omitempty"`
-	Tags     []string `json:"tags"`
-}
-
 func resourceKongConsumer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongConsumerCreate,
-		Read:   resourceKongConsumerRead,
-		Update: resourceKongConsumerUpdate,
-		Delete: resourceKongConsumerDelete,
+		CreateContext: resourceKongConsumerCreate,
+		ReadContext:   resourceKongConsumerRead,
+		UpdateContext: resourceKongConsumerUpdate,
+		DeleteContext: resourceKongConsumerDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStatePassthroughWithWorkspace,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
 			"username": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -53,22 +48,16 @@ func resourceKongConsumer() *schema.Resource {
 	}
 }
 
-func resourceKongConsumerCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	consumer := getConsumerFromResourceData(d)
 
-	createdConsumer := new(Consumer)
-
-	response, error := sling.New().BodyJSON(consumer).Post("consumers/").ReceiveSuccess(createdConsumer)
-	if error != nil {
-		return fmt.Errorf("error while creating consumer")
-	}
-
-	if response.StatusCode == http.StatusConflict {
-		return fmt.Errorf("409 Conflict - use terraform import to manage this consumer")
-	} else if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+	createdConsumer, err := c.Consumers.Create(ctx, consumer)
+	if client.IsConflict(err) {
+		return diag.Errorf("409 Conflict - use terraform import to manage this consumer")
+	} else if err != nil {
+		return errorDiagnostics("error while creating consumer", err)
 	}
 
 	setConsumerToResourceData(d, createdConsumer)
@@ -76,22 +65,15 @@ func resourceKongConsumerCreate(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceKongConsumerRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	id := d.Id()
-	consumer := new(Consumer)
-
-	response, error := sling.New().Path("consumers/").Get(id).ReceiveSuccess(consumer)
-	if error != nil {
-		return fmt.Errorf("error while updating consumer")
-	}
-
-	if response.StatusCode == http.StatusNotFound {
+	consumer, err := c.Consumers.Get(ctx, d.Id())
+	if client.IsNotFound(err) {
 		d.SetId("")
 		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	} else if err != nil {
+		return errorDiagnostics("error while reading consumer", err)
 	}
 
 	setConsumerToResourceData(d, consumer)
@@ -99,20 +81,14 @@ func resourceKongConsumerRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongConsumerUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	consumer := getConsumerFromResourceData(d)
 
-	updatedConsumer := new(Consumer)
-
-	response, error := sling.New().BodyJSON(consumer).Patch("consumers/").Path(consumer.ID).ReceiveSuccess(updatedConsumer)
-	if error != nil {
-		return fmt.Errorf("error while updating consumer")
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	updatedConsumer, err := c.Consumers.Update(ctx, consumer)
+	if err != nil {
+		return errorDiagnostics("error while updating consumer", err)
 	}
 
 	setConsumerToResourceData(d, updatedConsumer)
@@ -120,25 +96,19 @@ func resourceKongConsumerUpdate(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceKongConsumerDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
-
-	id := d.Id()
-
-	response, error := sling.New().Delete("consumers/").Path(id).ReceiveSuccess(nil)
-	if error != nil {
-		return fmt.Errorf("error while deleting consumer")
-	}
+func resourceKongConsumerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	err := c.Consumers.Delete(ctx, d.Id())
+	if err != nil {
+		return errorDiagnostics("error while deleting consumer", err)
 	}
 
 	return nil
 }
 
-func getConsumerFromResourceData(d *schema.ResourceData) *Consumer {
-	consumer := &Consumer{
+func getConsumerFromResourceData(d *schema.ResourceData) *client.Consumer {
+	consumer := &client.Consumer{
 		ID:       d.Id(),
 		Username: d.Get("username").(string),
 		CustomID: d.Get("custom_id").(string),
@@ -148,7 +118,7 @@ func getConsumerFromResourceData(d *schema.ResourceData) *Consumer {
 	return consumer
 }
 
-func setConsumerToResourceData(d *schema.ResourceData, consumer *Consumer) {
+func setConsumerToResourceData(d *schema.ResourceData, consumer *client.Consumer) {
 	d.SetId(consumer.ID)
 	d.Set("username", consumer.Username)
 	d.Set("custom_id", consumer.CustomID)
@@ -1,29 +1,24 @@
 package kong
 
 import (
-	"fmt"
-	"net/http"
+	"context"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-type ConsumerACLGroup struct {
-	ID       string   `json:"id,omitempty"`
-	Group    string   `json:"group,omitempty"`
-	Consumer string   `json:"-"`
-	Tags     []string `json:"tags"`
-}
-
 func resourceKongConsumerACLGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongConsumerACLGroupCreate,
-		Read:   resourceKongConsumerACLGroupRead,
-		Update: resourceKongConsumerACLGroupUpdate,
-		Delete: resourceKongConsumerACLGroupDelete,
+		CreateContext: resourceKongConsumerACLGroupCreate,
+		ReadContext:   resourceKongConsumerACLGroupRead,
+		UpdateContext: resourceKongConsumerACLGroupUpdate,
+		DeleteContext: resourceKongConsumerACLGroupDelete,
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
 			"group": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -45,20 +40,14 @@ func resourceKongConsumerACLGroup() *schema.Resource {
 	}
 }
 
-func resourceKongConsumerACLGroupCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerACLGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	consumerACLGroup := getConsumerACLGroupFromResourceData(d)
 
-	createdConsumerACLGroup := getConsumerACLGroupFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(consumerACLGroup).Path("consumers/").Path(consumerACLGroup.Consumer + "/").Post("acls/").ReceiveSuccess(createdConsumerACLGroup)
-	if error != nil {
-		return fmt.Errorf("error while creating consumer ACL group")
-	}
-
-	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+	createdConsumerACLGroup, err := c.Consumers.ACLGroups(consumerACLGroup.Consumer).Create(ctx, consumerACLGroup)
+	if err != nil {
+		return errorDiagnostics("error while creating consumer ACL group", err)
 	}
 
 	setConsumerACLGroupToResourceData(d, createdConsumerACLGroup)
@@ -66,21 +55,17 @@ func resourceKongConsumerACLGroupCreate(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func resourceKongConsumerACLGroupRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerACLGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	consumerACLGroup := getConsumerACLGroupFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(consumerACLGroup.Consumer + "/").Path("acls/").Get(consumerACLGroup.ID).ReceiveSuccess(consumerACLGroup)
-	if error != nil {
-		return fmt.Errorf("error while updating consumer ACL group")
-	}
-
-	if response.StatusCode == http.StatusNotFound {
+	consumerACLGroup, err := c.Consumers.ACLGroups(consumerACLGroup.Consumer).Get(ctx, consumerACLGroup.ID)
+	if client.IsNotFound(err) {
 		d.SetId("")
 		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	} else if err != nil {
+		return errorDiagnostics("error while reading consumer ACL group", err)
 	}
 
 	setConsumerACLGroupToResourceData(d, consumerACLGroup)
@@ -88,20 +73,14 @@ func resourceKongConsumerACLGroupRead(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func resourceKongConsumerACLGroupUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerACLGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	consumerACLGroup := getConsumerACLGroupFromResourceData(d)
 
-	updatedConsumerACLGroup := getConsumerACLGroupFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(consumerACLGroup).Path("consumers/").Path(consumerACLGroup.Consumer + "/").Patch("acls/").Path(consumerACLGroup.ID).ReceiveSuccess(updatedConsumerACLGroup)
-	if error != nil {
-		return fmt.Errorf("error while updating consumer ACL group")
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	updatedConsumerACLGroup, err := c.Consumers.ACLGroups(consumerACLGroup.Consumer).Update(ctx, consumerACLGroup)
+	if err != nil {
+		return errorDiagnostics("error while updating consumer ACL group", err)
 	}
 
 	setConsumerACLGroupToResourceData(d, updatedConsumerACLGroup)
@@ -109,25 +88,21 @@ func resourceKongConsumerACLGroupUpdate(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func resourceKongConsumerACLGroupDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongConsumerACLGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	consumerACLGroup := getConsumerACLGroupFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(consumerACLGroup.Consumer + "/").Path("acls/").Delete(consumerACLGroup.ID).ReceiveSuccess(nil)
-	if error != nil {
-		return fmt.Errorf("error while deleting consumer ACL group")
-	}
-
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	err := c.Consumers.ACLGroups(consumerACLGroup.Consumer).Delete(ctx, consumerACLGroup.ID)
+	if err != nil {
+		return errorDiagnostics("error while deleting consumer ACL group", err)
 	}
 
 	return nil
 }
 
-func getConsumerACLGroupFromResourceData(d *schema.ResourceData) *ConsumerACLGroup {
-	consumerACLGroup := &ConsumerACLGroup{
+func getConsumerACLGroupFromResourceData(d *schema.ResourceData) *client.ConsumerACLGroup {
+	consumerACLGroup := &client.ConsumerACLGroup{
 		ID:       d.Id(),
 		Group:    d.Get("group").(string),
 		Consumer: d.Get("consumer").(string),
@@ -137,7 +112,7 @@ func getConsumerACLGroupFromResourceData(d *schema.ResourceData) *ConsumerACLGro
 	return consumerACLGroup
 }
 
-func setConsumerACLGroupToResourceData(d *schema.ResourceData, consumerACLGroup *ConsumerACLGroup) {
+func setConsumerACLGroupToResourceData(d *schema.ResourceData, consumerACLGroup *client.ConsumerACLGroup) {
 	d.SetId(consumerACLGroup.ID)
 	d.Set("group", consumerACLGroup.Group)
 	d.Set("consumer", consumerACLGroup.Consumer)
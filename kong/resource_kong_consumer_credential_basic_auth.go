@@ -1,38 +1,33 @@
 package kong
 
 import (
+	"context"
 	"fmt"
-	"net/http"
 
 	"crypto/sha1"
 	"io"
 	"strings"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-type BasicAuthCredential struct {
-	ID       string   `json:"id,omitempty"`
-	Username string   `json:"username,omitempty"`
-	Password string   `json:"password,omitempty"`
-	Consumer string   `json:"-"`
-	Tags     []string `json:"tags"`
-}
-
 func resourceKongBasicAuthCredential() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongBasicAuthCredentialCreate,
-		Read:   resourceKongBasicAuthCredentialRead,
-		Update: resourceKongBasicAuthCredentialUpdate,
-		Delete: resourceKongBasicAuthCredentialDelete,
+		CreateContext: resourceKongBasicAuthCredentialCreate,
+		ReadContext:   resourceKongBasicAuthCredentialRead,
+		UpdateContext: resourceKongBasicAuthCredentialUpdate,
+		DeleteContext: resourceKongBasicAuthCredentialDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: ImportConsumerCredential,
+			StateContext: ImportConsumerCredential,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
 			"username": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -68,20 +63,14 @@ func resourceKongBasicAuthCredential() *schema.Resource {
 	}
 }
 
-func resourceKongBasicAuthCredentialCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongBasicAuthCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	basicAuthCredential := getBasicAuthCredentialFromResourceData(d)
 
-	createdBasicAuthCredential := getBasicAuthCredentialFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(basicAuthCredential).Path("consumers/").Path(basicAuthCredential.Consumer + "/").Post("basic-auth/").ReceiveSuccess(createdBasicAuthCredential)
-	if error != nil {
-		return fmt.Errorf("error while creating basicAuthCredential")
-	}
-
-	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+	createdBasicAuthCredential, err := c.Consumers.BasicAuthCredentials(basicAuthCredential.Consumer).Create(ctx, basicAuthCredential)
+	if err != nil {
+		return errorDiagnostics("error while creating basicAuthCredential", err)
 	}
 
 	setBasicAuthCredentialToResourceData(d, createdBasicAuthCredential)
@@ -89,21 +78,17 @@ func resourceKongBasicAuthCredentialCreate(d *schema.ResourceData, meta interfac
 	return nil
 }
 
-func resourceKongBasicAuthCredentialRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongBasicAuthCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	basicAuthCredential := getBasicAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(basicAuthCredential.Consumer + "/").Path("basic-auth/").Get(basicAuthCredential.ID).ReceiveSuccess(basicAuthCredential)
-	if error != nil {
-		return fmt.Errorf("error while updating basicAuthCredential")
-	}
-
-	if response.StatusCode == http.StatusNotFound {
+	basicAuthCredential, err := c.Consumers.BasicAuthCredentials(basicAuthCredential.Consumer).Get(ctx, basicAuthCredential.ID)
+	if client.IsNotFound(err) {
 		d.SetId("")
 		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	} else if err != nil {
+		return errorDiagnostics("error while reading basicAuthCredential", err)
 	}
 
 	setBasicAuthCredentialToResourceData(d, basicAuthCredential)
@@ -111,20 +96,14 @@ func resourceKongBasicAuthCredentialRead(d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func resourceKongBasicAuthCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongBasicAuthCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	basicAuthCredential := getBasicAuthCredentialFromResourceData(d)
 
-	updatedBasicAuthCredential := getBasicAuthCredentialFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(basicAuthCredential).Path("consumers/").Path(basicAuthCredential.Consumer + "/").Patch("basic-auth/").Path(basicAuthCredential.ID).ReceiveSuccess(updatedBasicAuthCredential)
-	if error != nil {
-		return fmt.Errorf("error while updating basicAuthCredential")
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	updatedBasicAuthCredential, err := c.Consumers.BasicAuthCredentials(basicAuthCredential.Consumer).Update(ctx, basicAuthCredential)
+	if err != nil {
+		return errorDiagnostics("error while updating basicAuthCredential", err)
 	}
 
 	setBasicAuthCredentialToResourceData(d, updatedBasicAuthCredential)
@@ -132,25 +111,21 @@ func resourceKongBasicAuthCredentialUpdate(d *schema.ResourceData, meta interfac
 	return nil
 }
 
-func resourceKongBasicAuthCredentialDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongBasicAuthCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	basicAuthCredential := getBasicAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(basicAuthCredential.Consumer + "/").Path("basic-auth/").Delete(basicAuthCredential.ID).ReceiveSuccess(nil)
-	if error != nil {
-		return fmt.Errorf("error while deleting basicAuthCredential")
-	}
-
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	err := c.Consumers.BasicAuthCredentials(basicAuthCredential.Consumer).Delete(ctx, basicAuthCredential.ID)
+	if err != nil {
+		return errorDiagnostics("error while deleting basicAuthCredential", err)
 	}
 
 	return nil
 }
 
-func getBasicAuthCredentialFromResourceData(d *schema.ResourceData) *BasicAuthCredential {
-	basicAuthCredential := &BasicAuthCredential{
+func getBasicAuthCredentialFromResourceData(d *schema.ResourceData) *client.BasicAuthCredential {
+	basicAuthCredential := &client.BasicAuthCredential{
 		ID:       d.Id(),
 		Username: d.Get("username").(string),
 		Password: d.Get("password").(string),
@@ -161,7 +136,7 @@ func getBasicAuthCredentialFromResourceData(d *schema.ResourceData) *BasicAuthCr
 	return basicAuthCredential
 }
 
-func setBasicAuthCredentialToResourceData(d *schema.ResourceData, basicAuthCredential *BasicAuthCredential) {
+func setBasicAuthCredentialToResourceData(d *schema.ResourceData, basicAuthCredential *client.BasicAuthCredential) {
 	d.SetId(basicAuthCredential.ID)
 	d.Set("username", basicAuthCredential.Username)
 	d.Set("password", basicAuthCredential.Password)
@@ -1,37 +1,29 @@
 package kong
 
 import (
-	"fmt"
-	"net/http"
+	"context"
 	"strings"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-type JWTCredential struct {
-	ID           string   `json:"id,omitempty"`
-	Key          string   `json:"key,omitempty"`
-	Algorithm    string   `json:"algorithm,omitempty"`
-	RSAPublicKey string   `json:"rsa_public_key,omitempty"`
-	Secret       string   `json:"secret,omitempty"`
-	Consumer     string   `json:"-"`
-	Tags         []string `json:"tags"`
-}
-
 func resourceKongJWTCredential() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongJWTCredentialCreate,
-		Read:   resourceKongJWTCredentialRead,
-		Update: resourceKongJWTCredentialUpdate,
-		Delete: resourceKongJWTCredentialDelete,
+		CreateContext: resourceKongJWTCredentialCreate,
+		ReadContext:   resourceKongJWTCredentialRead,
+		UpdateContext: resourceKongJWTCredentialUpdate,
+		DeleteContext: resourceKongJWTCredentialDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: ImportConsumerCredential,
+			StateContext: ImportConsumerCredential,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
 			"key": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -84,20 +76,14 @@ func resourceKongJWTCredential() *schema.Resource {
 	}
 }
 
-func resourceKongJWTCredentialCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongJWTCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	jwtCredential := getJWTCredentialFromResourceData(d)
 
-	createdJWTCredential := getJWTCredentialFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(jwtCredential).Path("consumers/").Path(jwtCredential.Consumer + "/").Post("jwt/").ReceiveSuccess(createdJWTCredential)
-	if error != nil {
-		return fmt.Errorf("error while creating jwtCredential")
-	}
-
-	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+	createdJWTCredential, err := c.Consumers.JWTCredentials(jwtCredential.Consumer).Create(ctx, jwtCredential)
+	if err != nil {
+		return errorDiagnostics("error while creating jwtCredential", err)
 	}
 
 	setJWTCredentialToResourceData(d, createdJWTCredential)
@@ -105,21 +91,17 @@ func resourceKongJWTCredentialCreate(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func resourceKongJWTCredentialRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongJWTCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	jwtCredential := getJWTCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(jwtCredential.Consumer + "/").Path("jwt/").Get(jwtCredential.ID).ReceiveSuccess(jwtCredential)
-	if error != nil {
-		return fmt.Errorf("error while updating jwtCredential")
-	}
-
-	if response.StatusCode == http.StatusNotFound {
+	jwtCredential, err := c.Consumers.JWTCredentials(jwtCredential.Consumer).Get(ctx, jwtCredential.ID)
+	if client.IsNotFound(err) {
 		d.SetId("")
 		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	} else if err != nil {
+		return errorDiagnostics("error while reading jwtCredential", err)
 	}
 
 	setJWTCredentialToResourceData(d, jwtCredential)
@@ -127,20 +109,14 @@ func resourceKongJWTCredentialRead(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceKongJWTCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongJWTCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	jwtCredential := getJWTCredentialFromResourceData(d)
 
-	updatedJWTCredential := getJWTCredentialFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(jwtCredential).Path("consumers/").Path(jwtCredential.Consumer + "/").Patch("jwt/").Path(jwtCredential.ID).ReceiveSuccess(updatedJWTCredential)
-	if error != nil {
-		return fmt.Errorf("error while updating jwtCredential")
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	updatedJWTCredential, err := c.Consumers.JWTCredentials(jwtCredential.Consumer).Update(ctx, jwtCredential)
+	if err != nil {
+		return errorDiagnostics("error while updating jwtCredential", err)
 	}
 
 	setJWTCredentialToResourceData(d, updatedJWTCredential)
@@ -148,25 +124,21 @@ func resourceKongJWTCredentialUpdate(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func resourceKongJWTCredentialDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongJWTCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	jwtCredential := getJWTCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(jwtCredential.Consumer + "/").Path("jwt/").Delete(jwtCredential.ID).ReceiveSuccess(nil)
-	if error != nil {
-		return fmt.Errorf("error while deleting jwtCredential")
-	}
-
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	err := c.Consumers.JWTCredentials(jwtCredential.Consumer).Delete(ctx, jwtCredential.ID)
+	if err != nil {
+		return errorDiagnostics("error while deleting jwtCredential", err)
 	}
 
 	return nil
 }
 
-func getJWTCredentialFromResourceData(d *schema.ResourceData) *JWTCredential {
-	jwtCredential := &JWTCredential{
+func getJWTCredentialFromResourceData(d *schema.ResourceData) *client.JWTCredential {
+	jwtCredential := &client.JWTCredential{
 		ID:           d.Id(),
 		Key:          d.Get("key").(string),
 		Algorithm:    d.Get("algorithm").(string),
@@ -179,7 +151,7 @@ func getJWTCredentialFromResourceData(d *schema.ResourceData) *JWTCredential {
 	return jwtCredential
 }
 
-func setJWTCredentialToResourceData(d *schema.ResourceData, jwtCredential *JWTCredential) {
+func setJWTCredentialToResourceData(d *schema.ResourceData, jwtCredential *client.JWTCredential) {
 	d.SetId(jwtCredential.ID)
 	d.Set("key", jwtCredential.Key)
 	d.Set("algorithm", jwtCredential.Algorithm)
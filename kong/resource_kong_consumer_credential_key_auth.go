@@ -1,34 +1,28 @@
 package kong
 
 import (
-	"fmt"
-	"net/http"
+	"context"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-type KeyAuthCredential struct {
-	ID       string   `json:"id,omitempty"`
-	Key      string   `json:"key,omitempty"`
-	Consumer string   `json:"-"`
-	TTL      int      `json:"ttl,omitempty"`
-	Tags     []string `json:"tags"`
-}
-
 func resourceKongKeyAuthCredential() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongKeyAuthCredentialCreate,
-		Read:   resourceKongKeyAuthCredentialRead,
-		Update: resourceKongKeyAuthCredentialUpdate,
-		Delete: resourceKongKeyAuthCredentialDelete,
+		CreateContext: resourceKongKeyAuthCredentialCreate,
+		ReadContext:   resourceKongKeyAuthCredentialRead,
+		UpdateContext: resourceKongKeyAuthCredentialUpdate,
+		DeleteContext: resourceKongKeyAuthCredentialDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: ImportConsumerCredential,
+			StateContext: ImportConsumerCredential,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
 			"key": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -58,20 +52,14 @@ func resourceKongKeyAuthCredential() *schema.Resource {
 	}
 }
 
-func resourceKongKeyAuthCredentialCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongKeyAuthCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	keyAuthCredential := getKeyAuthCredentialFromResourceData(d)
 
-	createdKeyAuthCredential := getKeyAuthCredentialFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(keyAuthCredential).Path("consumers/").Path(keyAuthCredential.Consumer + "/").Post("key-auth/").ReceiveSuccess(createdKeyAuthCredential)
-	if error != nil {
-		return fmt.Errorf("error while creating keyAuthCredential")
-	}
-
-	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+	createdKeyAuthCredential, err := c.Consumers.KeyAuthCredentials(keyAuthCredential.Consumer).Create(ctx, keyAuthCredential)
+	if err != nil {
+		return errorDiagnostics("error while creating keyAuthCredential", err)
 	}
 
 	setKeyAuthCredentialToResourceData(d, createdKeyAuthCredential)
@@ -79,21 +67,17 @@ func resourceKongKeyAuthCredentialCreate(d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func resourceKongKeyAuthCredentialRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongKeyAuthCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	keyAuthCredential := getKeyAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(keyAuthCredential.Consumer + "/").Path("key-auth/").Get(keyAuthCredential.ID).ReceiveSuccess(keyAuthCredential)
-	if error != nil {
-		return fmt.Errorf("error while updating keyAuthCredential")
-	}
-
-	if response.StatusCode == http.StatusNotFound {
+	keyAuthCredential, err := c.Consumers.KeyAuthCredentials(keyAuthCredential.Consumer).Get(ctx, keyAuthCredential.ID)
+	if client.IsNotFound(err) {
 		d.SetId("")
 		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	} else if err != nil {
+		return errorDiagnostics("error while reading keyAuthCredential", err)
 	}
 
 	setKeyAuthCredentialToResourceData(d, keyAuthCredential)
@@ -101,20 +85,14 @@ func resourceKongKeyAuthCredentialRead(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func resourceKongKeyAuthCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongKeyAuthCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	keyAuthCredential := getKeyAuthCredentialFromResourceData(d)
 
-	updatedKeyAuthCredential := getKeyAuthCredentialFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(keyAuthCredential).Path("consumers/").Path(keyAuthCredential.Consumer + "/").Patch("key-auth/").Path(keyAuthCredential.ID).ReceiveSuccess(updatedKeyAuthCredential)
-	if error != nil {
-		return fmt.Errorf("error while updating keyAuthCredential")
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	updatedKeyAuthCredential, err := c.Consumers.KeyAuthCredentials(keyAuthCredential.Consumer).Update(ctx, keyAuthCredential)
+	if err != nil {
+		return errorDiagnostics("error while updating keyAuthCredential", err)
 	}
 
 	setKeyAuthCredentialToResourceData(d, updatedKeyAuthCredential)
@@ -122,25 +100,21 @@ func resourceKongKeyAuthCredentialUpdate(d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func resourceKongKeyAuthCredentialDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongKeyAuthCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	keyAuthCredential := getKeyAuthCredentialFromResourceData(d)
 
-	response, error := sling.New().Path("consumers/").Path(keyAuthCredential.Consumer + "/").Path("key-auth/").Delete(keyAuthCredential.ID).ReceiveSuccess(nil)
-	if error != nil {
-		return fmt.Errorf("error while deleting keyAuthCredential")
-	}
-
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	err := c.Consumers.KeyAuthCredentials(keyAuthCredential.Consumer).Delete(ctx, keyAuthCredential.ID)
+	if err != nil {
+		return errorDiagnostics("error while deleting keyAuthCredential", err)
 	}
 
 	return nil
 }
 
-func getKeyAuthCredentialFromResourceData(d *schema.ResourceData) *KeyAuthCredential {
-	keyAuthCredential := &KeyAuthCredential{
+func getKeyAuthCredentialFromResourceData(d *schema.ResourceData) *client.KeyAuthCredential {
+	keyAuthCredential := &client.KeyAuthCredential{
 		ID:       d.Id(),
 		Key:      d.Get("key").(string),
 		Consumer: d.Get("consumer").(string),
@@ -151,7 +125,7 @@ func getKeyAuthCredentialFromResourceData(d *schema.ResourceData) *KeyAuthCreden
 	return keyAuthCredential
 }
 
-func setKeyAuthCredentialToResourceData(d *schema.ResourceData, keyAuthCredential *KeyAuthCredential) {
+func setKeyAuthCredentialToResourceData(d *schema.ResourceData, keyAuthCredential *client.KeyAuthCredential) {
 	d.SetId(keyAuthCredential.ID)
 	d.Set("key", keyAuthCredential.Key)
 	d.Set("consumer", keyAuthCredential.Consumer)
@@ -1,42 +1,33 @@
 package kong
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
-	"net/url"
-	"strings"
+	"reflect"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-// Plugin : Kong Service/API plugin request object structure
-type Plugin struct {
-	ID            string                 `json:"id,omitempty"`
-	Name          string                 `json:"name,omitempty"`
-	Configuration map[string]interface{} `json:"config,omitempty"`
-	Protocols     []string               `json:"protocols,omitempty"`
-	Service       map[string]string      `json:"service,omitempty"`
-	Route         map[string]string      `json:"route,omitempty"`
-	Consumer      map[string]string      `json:"consumer,omitempty"`
-	Tags          []string               `json:"tags"`
-	Enabled       bool                   `json:"enabled"`
-}
-
 func resourceKongPlugin() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongPluginCreate,
-		Read:   resourceKongPluginRead,
-		Update: resourceKongPluginUpdate,
-		Delete: resourceKongPluginDelete,
+		CreateContext: resourceKongPluginCreate,
+		ReadContext:   resourceKongPluginRead,
+		UpdateContext: resourceKongPluginUpdate,
+		DeleteContext: resourceKongPluginDelete,
+
+		CustomizeDiff: validatePluginConfig,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStatePassthroughWithWorkspace,
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: mergeSchemas(pluginScopeSchema(), map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -53,132 +44,297 @@ func resourceKongPlugin() *schema.Resource {
 			},
 
 			"config_json": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  nil,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+				Description:      "The plugin configuration as a JSON object. It is refreshed from Kong without the fields left to their defaults, so that any other change made in Kong shows as a difference. The fields removed from it are reset to their defaults.",
 			},
+		}),
+	}
+}
 
-			"service": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     nil,
-				Description: "The id of the route to scope this plugin to. f set, the plugin will only activate when receiving requests via one of the routes belonging to the specified Service",
-			},
+// pluginScopeSchema returns the attributes shared by every plugin resource,
+// which scope the plugin to a service, a route or a consumer.
+func pluginScopeSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"workspace": workspaceSchema(),
+
+		"protocols": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Computed:    true,
+			Description: "A list of the request protocols that will trigger this plugin. Defaults to the protocols supported by the plugin.",
+		},
 
-			"route": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     nil,
-				Description: "The id of the route to scope this plugin to. If set, the plugin will only activate when receiving requests via the specified route",
-			},
+		"service": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     nil,
+			Description: "The id of the route to scope this plugin to. f set, the plugin will only activate when receiving requests via one of the routes belonging to the specified Service",
+		},
 
-			"consumer": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     nil,
-				Description: "The id of the consumer to scope this plugin to. If set, the plugin will activate only for requests where the specified has been authenticated",
-			},
+		"route": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     nil,
+			Description: "The id of the route to scope this plugin to. If set, the plugin will only activate when receiving requests via the specified route",
+		},
 
-			"consumer_username": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     nil,
-				Description: "The unique username of the Consumer. Can be used instead of ID.",
-			},
+		"consumer": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     nil,
+			Description: "The id of the consumer to scope this plugin to. If set, the plugin will activate only for requests where the specified has been authenticated",
+		},
 
-			"tags": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Description: "An optional set of strings associated with the Service for grouping and filtering.",
-			},
+		"consumer_username": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     nil,
+			Description: "The unique username of the Consumer. Can be used instead of ID.",
+		},
 
-			"enabled": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Description: "Whether the Service is active",
-				Default:     true,
-			},
+		"tags": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "An optional set of strings associated with the Service for grouping and filtering.",
 		},
+
+		"enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether the Service is active",
+			Default:     true,
+		},
+	}
+}
+
+// mergeSchemas returns the attributes of every given schema, the later ones
+// overriding the earlier ones.
+func mergeSchemas(schemas ...map[string]*schema.Schema) map[string]*schema.Schema {
+	merged := map[string]*schema.Schema{}
+	for _, s := range schemas {
+		for name, attribute := range s {
+			merged[name] = attribute
+		}
 	}
+
+	return merged
 }
 
-func resourceKongPluginCreate(d *schema.ResourceData, meta interface{}) error {
-	request := buildModifyRequest(d, meta)
-	p := &Plugin{}
+func resourceKongPluginCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	response, err := request.Post("/plugins/").ReceiveSuccess(p)
+	configSchema, err := pluginConfigSchema(ctx, c, d.Get("name").(string))
 	if err != nil {
-		return fmt.Errorf("error while creating plugin: " + err.Error())
+		return diag.FromErr(err)
 	}
 
-	if response.StatusCode == http.StatusConflict {
-		return fmt.Errorf("409 Conflict - use terraform import to manage this plugin")
-	} else if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	plugin, err := buildModifyRequest(d, configSchema)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	return setPluginToResourceData(d, p)
+	createdPlugin, err := c.Plugins.Create(ctx, plugin)
+	if client.IsConflict(err) {
+		return diag.Errorf("409 Conflict - use terraform import to manage this plugin")
+	} else if err != nil {
+		return errorDiagnostics("error while creating plugin", err)
+	}
+
+	return diag.FromErr(setPluginToResourceData(d, createdPlugin, configSchema))
 }
 
-func resourceKongPluginRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongPluginRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	p := &Plugin{}
+	plugin, err := c.Plugins.Get(ctx, d.Id())
+	if client.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return errorDiagnostics("error while reading plugin", err)
+	}
 
-	response, err := sling.New().Path("plugins/").Get(d.Id()).ReceiveSuccess(p)
+	configSchema, err := pluginConfigSchema(ctx, c, plugin.Name)
 	if err != nil {
-		return fmt.Errorf("error while updating plugin: " + err.Error())
+		return diag.FromErr(err)
 	}
 
-	if response.StatusCode == http.StatusNotFound {
-		d.SetId("")
-		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	return diag.FromErr(setPluginToResourceData(d, plugin, configSchema))
+}
+
+func resourceKongPluginUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	configSchema, err := pluginConfigSchema(ctx, c, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	plugin, err := buildModifyRequest(d, configSchema)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	updatedPlugin, err := c.Plugins.Update(ctx, plugin)
+	if err != nil {
+		return errorDiagnostics("error while updating plugin", err)
 	}
 
-	return setPluginToResourceData(d, p)
+	return diag.FromErr(setPluginToResourceData(d, updatedPlugin, configSchema))
 }
 
-func resourceKongPluginUpdate(d *schema.ResourceData, meta interface{}) error {
-	request := buildModifyRequest(d, meta)
+func resourceKongPluginDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	p := &Plugin{}
+	err := c.Plugins.Delete(ctx, d.Id())
+	if err != nil {
+		return errorDiagnostics("error while deleting plugin", err)
+	}
+
+	return nil
+}
+
+// pluginConfigSchema returns the field of the plugin schema describing its
+// configuration.
+func pluginConfigSchema(ctx context.Context, c *client.Client, name string) (*client.SchemaField, error) {
+	pluginSchema, err := c.Plugins.Schema(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the schema of the %s plugin: %w", name, err)
+	}
 
-	response, err := request.Path("plugins/").Patch(d.Id()).ReceiveSuccess(p)
+	return pluginSchema.Config(), nil
+}
+
+func buildModifyRequest(d *schema.ResourceData, configSchema *client.SchemaField) (*client.Plugin, error) {
+	plugin := buildPluginScope(d)
+	plugin.Name = d.Get("name").(string)
+
+	config, err := parsePluginConfig(d.Get("config_json").(string))
 	if err != nil {
-		return fmt.Errorf("error while updating plugin: " + err.Error())
+		return nil, err
+	}
+
+	// Kong merges the configuration of an update into the current one, the
+	// fields no longer set must be nulled to get back their defaults.
+	if d.Id() != "" {
+		old, _ := d.GetChange("config_json")
+		if previous, err := parsePluginConfig(old.(string)); err == nil && len(previous) > 0 {
+			if config == nil {
+				config = map[string]interface{}{}
+			}
+			nullRemovedFields(previous, config, configSchema)
+		}
+	}
+	plugin.Configuration = config
+
+	return plugin, nil
+}
+
+// nullRemovedFields sets the fields of previous missing from config to null.
+// Kong merges records field by field as well, so their removed fields are
+// nulled too.
+func nullRemovedFields(previous, config map[string]interface{}, record *client.SchemaField) {
+	for name, value := range previous {
+		current, ok := config[name]
+		if !ok {
+			config[name] = nil
+			continue
+		}
+
+		field := record.Field(name)
+		if field == nil || field.Type != "record" {
+			continue
+		}
+		previousRecord, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if currentRecord, ok := current.(map[string]interface{}); ok {
+			nullRemovedFields(previousRecord, currentRecord, field)
+		}
 	}
+}
 
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+// parsePluginConfig decodes config_json, which must hold a JSON object.
+func parsePluginConfig(configJSON string) (map[string]interface{}, error) {
+	if configJSON == "" {
+		return nil, nil
 	}
 
-	return setPluginToResourceData(d, p)
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+		return nil, fmt.Errorf("config_json must be a JSON object: %w", err)
+	}
+
+	return config, nil
 }
 
-func resourceKongPluginDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+// validatePluginConfig checks the planned plugin against the schema of the
+// plugin on the Kong node, so that Kong's validation errors show up at plan
+// time rather than during apply.
+func validatePluginConfig(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("name", "config_json", "protocols", "service", "route", "consumer") {
+		return nil
+	}
+	if !d.NewValueKnown("name") || !d.NewValueKnown("config_json") {
+		return nil
+	}
 
-	response, error := sling.New().Path("plugins/").Delete(d.Id()).ReceiveSuccess(nil)
-	if error != nil {
-		return fmt.Errorf("error while deleting plugin: " + error.Error())
+	config, err := parsePluginConfig(d.Get("config_json").(string))
+	if err != nil {
+		return cty.GetAttrPath("config_json").NewError(err)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	plugin := &client.Plugin{
+		Name:          d.Get("name").(string),
+		Configuration: config,
 	}
 
-	return nil
+	return validatePlugin(ctx, d, meta, plugin, func(err error) diag.Diagnostics {
+		return errorDiagnostics("invalid "+plugin.Name+" plugin", err)
+	})
 }
 
-func buildModifyRequest(d *schema.ResourceData, meta interface{}) *sling.Sling {
-	request := meta.(*sling.Sling).New()
+// validatePlugin completes the plugin with the pluginScopeSchema attributes
+// planned in d and has Kong validate it, diagnostics describing the errors
+// Kong reports. A plugin which can't be validated fails the plan too.
+func validatePlugin(ctx context.Context, d *schema.ResourceDiff, meta interface{}, plugin *client.Plugin, diagnostics func(error) diag.Diagnostics) error {
+	plugin.Enabled = d.Get("enabled").(bool)
+	if d.NewValueKnown("protocols") {
+		plugin.Protocols = helper.ConvertInterfaceArrToStrings(d.Get("protocols").([]interface{}))
+	}
+	// Entities created in the same apply aren't known yet, and only matter
+	// to the few plugins which can't be scoped to them.
+	if d.NewValueKnown("service") {
+		plugin.Service = helper.SetObjectID(d.Get("service").(string))
+	}
+	if d.NewValueKnown("route") {
+		plugin.Route = helper.SetObjectID(d.Get("route").(string))
+	}
+	if d.NewValueKnown("consumer") {
+		plugin.Consumer = helper.SetObjectID(d.Get("consumer").(string))
+	}
+
+	err := clientForDiff(d, meta).Plugins.Validate(ctx, plugin)
+	if client.IsValidation(err) {
+		return diagnosticsError(diagnostics(err))
+	} else if err != nil {
+		return fmt.Errorf("unable to validate the %s plugin configuration: %w", plugin.Name, err)
+	}
 
-	plugin := &Plugin{
+	return nil
+}
+
+// buildPluginScope returns the plugin, without its name and configuration,
+// described by the pluginScopeSchema attributes of d.
+func buildPluginScope(d *schema.ResourceData) *client.Plugin {
+	return &client.Plugin{
 		ID:        d.Id(),
-		Name:      d.Get("name").(string),
 		Protocols: helper.ConvertInterfaceArrToStrings(d.Get("protocols").([]interface{})),
 		Service:   helper.SetObjectID(d.Get("service").(string)),
 		Route:     helper.SetObjectID(d.Get("route").(string)),
@@ -186,40 +342,127 @@ func buildModifyRequest(d *schema.ResourceData, meta interface{}) *sling.Sling {
 		Tags:      helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
 		Enabled:   d.Get("enabled").(bool),
 	}
+}
 
-	if c, ok := d.GetOk("config_json"); ok {
-		config := make(map[string]interface{})
-		err := json.Unmarshal([]byte(c.(string)), &config)
-		if err != nil {
-			fmt.Printf("JSON is invalid")
-		}
-
-		plugin.Configuration = config
-
-		request = request.BodyJSON(plugin)
-	} else {
-		form := url.Values{
-			"name": {plugin.Name},
-		}
-
-		body := strings.NewReader(form.Encode())
+func setPluginToResourceData(d *schema.ResourceData, plugin *client.Plugin, configSchema *client.SchemaField) error {
+	_ = d.Set("name", plugin.Name)
 
-		request = request.Body(body).Set("Content-Type", "application/x-www-form-urlencoded")
+	config, err := refreshPluginConfig(d.Get("config_json").(string), plugin.Configuration, configSchema)
+	if err != nil {
+		return err
 	}
+	_ = d.Set("config_json", config)
 
-	return request
+	return setPluginScopeToResourceData(d, plugin)
 }
 
-func setPluginToResourceData(d *schema.ResourceData, plugin *Plugin) error {
+// setPluginScopeToResourceData sets the pluginScopeSchema attributes of d.
+func setPluginScopeToResourceData(d *schema.ResourceData, plugin *client.Plugin) error {
 	d.SetId(plugin.ID)
 
-	_ = d.Set("name", plugin.Name)
 	_ = d.Set("protocols", plugin.Protocols)
-	_ = d.Set("service", plugin.Service)
-	_ = d.Set("route", plugin.Route)
-	_ = d.Set("consumer", plugin.Consumer)
+	_ = d.Set("service", plugin.Service["id"])
+	_ = d.Set("route", plugin.Route["id"])
+	// Kong only returns the consumer id, which must not replace the username
+	// the plugin was scoped with.
+	if d.Get("consumer_username").(string) == "" {
+		_ = d.Set("consumer", plugin.Consumer["id"])
+	}
 	_ = d.Set("tags", plugin.Tags)
 	_ = d.Set("enabled", plugin.Enabled)
 
 	return nil
 }
+
+// refreshPluginConfig returns the JSON of the plugin configuration held by
+// Kong without the fields equal to their default in the plugin schema, so
+// that the defaults filled in by Kong don't show as differences. The fields
+// set by the specified configuration are kept whatever their value. Without
+// a specified configuration, a configuration left to its defaults is empty.
+func refreshPluginConfig(specified string, config map[string]interface{}, configSchema *client.SchemaField) (string, error) {
+	var spec interface{}
+	if specified != "" {
+		if err := json.Unmarshal([]byte(specified), &spec); err != nil {
+			return "", err
+		}
+	}
+
+	refreshed := withoutDefaults(spec, config, configSchema)
+	if specified == "" && len(refreshed) == 0 {
+		return "", nil
+	}
+
+	b, err := json.Marshal(refreshed)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// withoutDefaults returns the fields of the record config which are set by
+// spec or differ from their default, walking into nested records.
+func withoutDefaults(spec interface{}, config map[string]interface{}, record *client.SchemaField) map[string]interface{} {
+	specObject, _ := spec.(map[string]interface{})
+
+	fields := map[string]interface{}{}
+	for name, value := range config {
+		specValue, specified := specObject[name]
+		field := record.Field(name)
+
+		if nested, ok := value.(map[string]interface{}); ok && field != nil && field.Type == "record" {
+			if nested = withoutDefaults(specValue, nested, field); specified || len(nested) > 0 {
+				fields[name] = nested
+			}
+			continue
+		}
+
+		if specified || !isSchemaDefault(field, value) {
+			fields[name] = value
+		}
+	}
+
+	return fields
+}
+
+// isSchemaDefault reports whether value is the default of the field, null
+// and empty arrays and maps being alike.
+func isSchemaDefault(field *client.SchemaField, value interface{}) bool {
+	var def interface{}
+	if field != nil {
+		def = field.Default
+	}
+
+	if isEmptyJSON(def) && isEmptyJSON(value) {
+		return true
+	}
+
+	return reflect.DeepEqual(def, value)
+}
+
+func isEmptyJSON(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+
+	return false
+}
+
+// suppressEquivalentJSON ignores the differences between two JSON documents
+// which only differ in formatting or key order.
+func suppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	var oldValue, newValue interface{}
+	if err := json.Unmarshal([]byte(old), &oldValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newValue); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(oldValue, newValue)
+}
@@ -0,0 +1,32 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKongPluginACL() *schema.Resource {
+	return resourceKongTypedPlugin("acl", map[string]*schema.Schema{
+		"allow": {
+			Type:         schema.TypeList,
+			Elem:         &schema.Schema{Type: schema.TypeString},
+			Optional:     true,
+			ExactlyOneOf: []string{"config.0.allow", "config.0.deny"},
+			Description:  "The consumer groups allowed to use the service or route. Conflicts with deny.",
+		},
+
+		"deny": {
+			Type:         schema.TypeList,
+			Elem:         &schema.Schema{Type: schema.TypeString},
+			Optional:     true,
+			ExactlyOneOf: []string{"config.0.allow", "config.0.deny"},
+			Description:  "The consumer groups denied the use of the service or route. Conflicts with allow.",
+		},
+
+		"hide_groups_header": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to hide the X-Consumer-Groups header from the upstream service.",
+		},
+	}, nil)
+}
@@ -0,0 +1,63 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginCORS() *schema.Resource {
+	return resourceKongTypedPlugin("cors", map[string]*schema.Schema{
+		"origins": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "The allowed domains for the Access-Control-Allow-Origin header. Entries may be regexes. Any origin is allowed when empty.",
+		},
+
+		"methods": {
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"GET", "HEAD", "PUT", "PATCH", "POST", "DELETE", "OPTIONS", "TRACE", "CONNECT"}, false),
+			},
+			Optional:    true,
+			Computed:    true,
+			Description: "The values of the Access-Control-Allow-Methods header. Defaults to every method.",
+		},
+
+		"headers": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "The values of the Access-Control-Allow-Headers header. Defaults to the Access-Control-Request-Headers header of the request.",
+		},
+
+		"exposed_headers": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "The values of the Access-Control-Expose-Headers header.",
+		},
+
+		"credentials": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to send the Access-Control-Allow-Credentials header with true as value.",
+		},
+
+		"max_age": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "How long in seconds the results of the preflight request can be cached.",
+		},
+
+		"preflight_continue": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to proxy the OPTIONS preflight requests to the upstream service.",
+		},
+	}, nil)
+}
@@ -0,0 +1,53 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ipRestrictionAttributeVersions lists the configuration fields which
+// appeared after minimumKongVersion.
+var ipRestrictionAttributeVersions = attributeVersions{
+	"config.status":  {Major: 3},
+	"config.message": {Major: 3},
+}
+
+func resourceKongPluginIPRestriction() *schema.Resource {
+	ipOrCIDR := &schema.Schema{
+		Type:         schema.TypeString,
+		ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR),
+	}
+
+	return resourceKongTypedPlugin("ip-restriction", map[string]*schema.Schema{
+		"allow": {
+			Type:         schema.TypeList,
+			Elem:         ipOrCIDR,
+			Optional:     true,
+			AtLeastOneOf: []string{"config.0.allow", "config.0.deny"},
+			Description:  "The IPs or CIDR ranges allowed. Takes precedence over deny.",
+		},
+
+		"deny": {
+			Type:         schema.TypeList,
+			Elem:         ipOrCIDR,
+			Optional:     true,
+			AtLeastOneOf: []string{"config.0.allow", "config.0.deny"},
+			Description:  "The IPs or CIDR ranges denied.",
+		},
+
+		"status": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(100, 599),
+			Description:  "The status code of the responses to rejected requests. Requires Kong 3.0 or newer.",
+		},
+
+		"message": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The message of the responses to rejected requests. Requires Kong 3.0 or newer.",
+		},
+	}, ipRestrictionAttributeVersions)
+}
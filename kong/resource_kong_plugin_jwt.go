@@ -0,0 +1,80 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginJWT() *schema.Resource {
+	return resourceKongTypedPlugin("jwt", map[string]*schema.Schema{
+		"uri_param_names": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Computed:    true,
+			Description: "The query parameters carrying the JWT. Defaults to [\"jwt\"].",
+		},
+
+		"cookie_names": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Computed:    true,
+			Description: "The cookies carrying the JWT.",
+		},
+
+		"header_names": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Computed:    true,
+			Description: "The headers carrying the JWT. Defaults to [\"authorization\"].",
+		},
+
+		"claims_to_verify": {
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"exp", "nbf"}, false),
+			},
+			Optional:    true,
+			Description: "The registered claims to verify: exp and/or nbf.",
+		},
+
+		"key_claim_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "iss",
+			Description: "The claim holding the key identifying the credential. Defaults to iss.",
+		},
+
+		"secret_is_base64": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether the credential secrets are base64 encoded.",
+		},
+
+		"anonymous": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsUUID,
+			Description:  "The id of the consumer used when authentication fails. Requests are rejected with a 401 when empty.",
+		},
+
+		"run_on_preflight": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether to authenticate the OPTIONS preflight requests. Defaults to true.",
+		},
+
+		"maximum_expiration": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntBetween(0, 31536000),
+			Description:  "The maximum lifetime in seconds of the JWT, which requires exp in claims_to_verify. 0 disables the check.",
+		},
+	}, nil)
+}
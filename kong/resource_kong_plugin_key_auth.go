@@ -0,0 +1,61 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginKeyAuth() *schema.Resource {
+	return resourceKongTypedPlugin("key-auth", map[string]*schema.Schema{
+		"key_names": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Computed:    true,
+			MinItems:    1,
+			Description: "The names of the headers, query parameters or body fields carrying the key. Defaults to [\"apikey\"].",
+		},
+
+		"hide_credentials": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to remove the key from the request before proxying it.",
+		},
+
+		"anonymous": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsUUID,
+			Description:  "The id of the consumer used when authentication fails. Requests are rejected with a 401 when empty.",
+		},
+
+		"key_in_header": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether to read the key from the request headers. Defaults to true.",
+		},
+
+		"key_in_query": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether to read the key from the query string. Defaults to true.",
+		},
+
+		"key_in_body": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to read the key from the request body.",
+		},
+
+		"run_on_preflight": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether to authenticate the OPTIONS preflight requests. Defaults to true.",
+		},
+	}, nil)
+}
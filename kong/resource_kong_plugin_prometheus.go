@@ -0,0 +1,39 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// prometheusAttributeVersions lists the configuration fields which appeared
+// after minimumKongVersion.
+var prometheusAttributeVersions = attributeVersions{
+	"config.status_code_metrics":     {Major: 3},
+	"config.latency_metrics":         {Major: 3},
+	"config.bandwidth_metrics":       {Major: 3},
+	"config.upstream_health_metrics": {Major: 3},
+}
+
+func resourceKongPluginPrometheus() *schema.Resource {
+	metrics := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: description + " Requires Kong 3.0 or newer.",
+		}
+	}
+
+	return resourceKongTypedPlugin("prometheus", map[string]*schema.Schema{
+		"per_consumer": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to collect the metrics of every consumer.",
+		},
+
+		"status_code_metrics":     metrics("Whether to collect the status codes of the responses."),
+		"latency_metrics":         metrics("Whether to collect the latencies of the requests."),
+		"bandwidth_metrics":       metrics("Whether to collect the bandwidth of the requests and responses."),
+		"upstream_health_metrics": metrics("Whether to collect the health of the upstream targets."),
+	}, prometheusAttributeVersions)
+}
@@ -0,0 +1,115 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKongPluginRateLimiting() *schema.Resource {
+	limit := func(period string) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			AtLeastOneOf: rateLimitingPeriods,
+			Description:  "The number of HTTP requests that can be made per " + period + ".",
+		}
+	}
+
+	return resourceKongTypedPlugin("rate-limiting", map[string]*schema.Schema{
+		"second": limit("second"),
+		"minute": limit("minute"),
+		"hour":   limit("hour"),
+		"day":    limit("day"),
+		"month":  limit("month"),
+		"year":   limit("year"),
+
+		"limit_by": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "consumer",
+			ValidateFunc: validation.StringInSlice([]string{"consumer", "credential", "ip", "service", "header", "path"}, false),
+			Description:  "The entity used to aggregate the limits: consumer (default), credential, ip, service, header or path. Falls back to ip when the entity can't be determined.",
+		},
+
+		"header_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The header used when limit_by is header.",
+		},
+
+		"path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The path used when limit_by is path.",
+		},
+
+		"policy": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"local", "cluster", "redis"}, false),
+			Description:  "Where the counters are stored: local, cluster or redis. The default depends on the Kong release.",
+		},
+
+		"fault_tolerant": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether to proxy requests when the counters store is unavailable. Defaults to true.",
+		},
+
+		"hide_client_headers": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to hide the rate limiting headers from the responses.",
+		},
+
+		"redis_host": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The Redis host used by the redis policy.",
+		},
+
+		"redis_port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      6379,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "The Redis port used by the redis policy. Defaults to 6379.",
+		},
+
+		"redis_password": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The Redis password used by the redis policy.",
+		},
+
+		"redis_timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      2000,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The timeout in milliseconds of the Redis commands. Defaults to 2000.",
+		},
+
+		"redis_database": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The Redis database used by the redis policy. Defaults to 0.",
+		},
+	}, nil)
+}
+
+var rateLimitingPeriods = []string{
+	"config.0.second",
+	"config.0.minute",
+	"config.0.hour",
+	"config.0.day",
+	"config.0.month",
+	"config.0.year",
+}
@@ -0,0 +1,61 @@
+package kong
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKongPluginRequestTransformer() *schema.Resource {
+	transformation := func(description, format string, uri bool) *schema.Schema {
+		fields := map[string]*schema.Schema{
+			"headers": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "The headers, as " + format + ".",
+			},
+			"querystring": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "The query string parameters, as " + format + ".",
+			},
+			"body": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "The body parameters, as " + format + ". Only applies to JSON, form-encoded and multipart bodies.",
+			},
+		}
+
+		if uri {
+			fields["uri"] = &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path replacing the upstream request URI.",
+			}
+		}
+
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem:        &schema.Resource{Schema: fields},
+			Description: description,
+		}
+	}
+
+	return resourceKongTypedPlugin("request-transformer", map[string]*schema.Schema{
+		"http_method": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The method of the upstream request.",
+		},
+
+		"remove":  transformation("The parameters removed from the request.", "names", false),
+		"rename":  transformation("The parameters renamed in the request.", "old_name:new_name pairs", false),
+		"replace": transformation("The parameters whose value is replaced, when present in the request.", "name:value pairs", true),
+		"add":     transformation("The parameters added to the request, when not already present.", "name:value pairs", false),
+		"append":  transformation("The values appended to the parameters of the request.", "name:value pairs", false),
+	}, nil)
+}
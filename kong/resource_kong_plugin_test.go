@@ -0,0 +1,145 @@
+package kong
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+)
+
+// rateLimitingSchema is an excerpt of the schema Kong returns for the
+// rate-limiting plugin.
+const rateLimitingSchema = `{"fields": [
+	{"consumer": {"type": "foreign", "reference": "consumers"}},
+	{"config": {"type": "record", "fields": [
+		{"minute": {"type": "number", "gt": 0}},
+		{"hour": {"type": "number", "gt": 0}},
+		{"policy": {"type": "string", "default": "local"}},
+		{"fault_tolerant": {"type": "boolean", "default": true}},
+		{"header_name": {"type": "string"}},
+		{"limit_by": {"type": "string", "default": "consumer"}},
+		{"error_code": {"type": "number", "default": 429}},
+		{"path": {"type": "string"}},
+		{"redis": {"type": "record", "fields": [
+			{"host": {"type": "string"}},
+			{"port": {"type": "integer", "default": 6379}},
+			{"ssl": {"type": "boolean", "default": false}}
+		]}},
+		{"hide_client_headers": {"type": "boolean", "default": false}},
+		{"methods": {"type": "array", "elements": {"type": "string"}}}
+	]}}
+]}`
+
+// rateLimitingConfig is the configuration Kong returns for a rate-limiting
+// plugin created with a minute limit and a Redis host.
+const rateLimitingConfig = `{
+	"minute": 5, "hour": null, "policy": "redis", "fault_tolerant": true,
+	"header_name": null, "limit_by": "consumer", "error_code": 429, "path": null,
+	"redis": {"host": "redis.internal", "port": 6379, "ssl": false},
+	"hide_client_headers": false, "methods": []
+}`
+
+func testRateLimitingSchema(t *testing.T) *client.SchemaField {
+	t.Helper()
+
+	var pluginSchema client.PluginSchema
+	if err := json.Unmarshal([]byte(rateLimitingSchema), &pluginSchema); err != nil {
+		t.Fatal(err)
+	}
+
+	return pluginSchema.Config()
+}
+
+func TestRefreshPluginConfig(t *testing.T) {
+	configSchema := testRateLimitingSchema(t)
+
+	tests := []struct {
+		name      string
+		specified string
+		config    string
+		refreshed string
+	}{
+		{
+			name:      "defaults left out",
+			specified: `{"minute": 5, "policy": "redis", "redis": {"host": "redis.internal"}}`,
+			config:    rateLimitingConfig,
+			refreshed: `{"minute": 5, "policy": "redis", "redis": {"host": "redis.internal"}}`,
+		},
+		{
+			name:      "specified defaults kept",
+			specified: `{"minute": 5, "policy": "redis", "limit_by": "consumer", "redis": {"host": "redis.internal", "port": 6379}}`,
+			config:    rateLimitingConfig,
+			refreshed: `{"minute": 5, "policy": "redis", "limit_by": "consumer", "redis": {"host": "redis.internal", "port": 6379}}`,
+		},
+		{
+			name:      "fields changed in Kong",
+			specified: `{"minute": 5, "policy": "redis", "redis": {"host": "redis.internal"}}`,
+			config:    `{"minute": 5, "policy": "redis", "error_code": 503, "methods": ["GET"], "redis": {"host": "redis.internal", "ssl": true}}`,
+			refreshed: `{"minute": 5, "policy": "redis", "error_code": 503, "methods": ["GET"], "redis": {"host": "redis.internal", "ssl": true}}`,
+		},
+		{
+			name:      "imported",
+			specified: ``,
+			config:    rateLimitingConfig,
+			refreshed: `{"minute": 5, "policy": "redis", "redis": {"host": "redis.internal"}}`,
+		},
+		{
+			name:      "unspecified and left to defaults",
+			specified: ``,
+			config:    `{"minute": null, "policy": "local", "redis": {"host": null, "port": 6379}}`,
+			refreshed: ``,
+		},
+		{
+			name:      "specified and left to defaults",
+			specified: `{}`,
+			config:    `{"minute": null, "policy": "local", "redis": {"host": null, "port": 6379}}`,
+			refreshed: `{}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var config map[string]interface{}
+			if err := json.Unmarshal([]byte(test.config), &config); err != nil {
+				t.Fatal(err)
+			}
+
+			refreshed, err := refreshPluginConfig(test.specified, config, configSchema)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.refreshed == "" || refreshed == "" {
+				if refreshed != test.refreshed {
+					t.Errorf("refreshPluginConfig() = %q, want %q", refreshed, test.refreshed)
+				}
+				return
+			}
+			if !suppressEquivalentJSON("config_json", refreshed, test.refreshed, nil) {
+				t.Errorf("refreshPluginConfig() = %s, want %s", refreshed, test.refreshed)
+			}
+		})
+	}
+}
+
+func TestNullRemovedFields(t *testing.T) {
+	configSchema := testRateLimitingSchema(t)
+
+	var previous, config, want map[string]interface{}
+	for document, value := range map[string]*map[string]interface{}{
+		`{"minute": 5, "hour": 100, "redis": {"host": "redis.internal", "ssl": true}, "methods": ["GET"]}`: &previous,
+		`{"minute": 5, "redis": {"host": "redis.internal"}}`:                                               &config,
+		`{"minute": 5, "hour": null, "redis": {"host": "redis.internal", "ssl": null}, "methods": null}`:   &want,
+	} {
+		if err := json.Unmarshal([]byte(document), value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	nullRemovedFields(previous, config, configSchema)
+
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("nullRemovedFields() = %v, want %v", config, want)
+	}
+}
@@ -1,49 +1,61 @@
 package kong
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
+	"regexp"
+	"regexp/syntax"
+	"strings"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-// Route : Kong Route request object structure
-type Route struct {
-	ID                      string              `json:"id,omitempty"`
-	Name                    string              `json:"name,omitempty"`
-	Protocols               []string            `json:"protocols"`
-	Methods                 []string            `json:"methods"`
-	Hosts                   []string            `json:"hosts"`
-	Paths                   []string            `json:"paths"`
-	Headers                 map[string][]string `json:"headers"`
-	HttpsRedirectStatusCode int                 `json:"https_redirect_status_code,omitempty"`
-	RegexPriority           int                 `json:"regex_priority"`
-	StripPath               bool                `json:"strip_path,omitempty"`
-	PathHandling            string              `json:"path_handling,omitempty"`
-	PreserveHost            bool                `json:"preserve_host,omitempty"`
-	RequestBuffering        bool                `json:"request_buffering"`
-	ResponseBuffering       bool                `json:"response_buffering"`
-	SNIs                    []string            `json:"snis,omitempty"`
-	// Sources                 []string            `json:"sources,omitempty"`
-	// Destinations            []string            `json:"destinations,omitempty"`
-	Tags    []string `json:"tags"`
-	Service Service  `json:"service,omitempty"`
+// routeAttributeVersions lists the attributes which appeared after
+// minimumKongVersion.
+var routeAttributeVersions = attributeVersions{
+	"request_buffering":  {Major: 2, Minor: 3},
+	"response_buffering": {Major: 2, Minor: 3},
+	"expression":         {Major: 3},
+	"priority":           {Major: 3},
 }
 
+// traditionalMatchers are the attributes matching requests with the
+// traditional router, which can't be combined with an expression.
+var traditionalMatchers = []string{"methods", "hosts", "paths", "header", "snis", "source", "destination"}
+
+// regexPathCharacters hint that a path is meant as a regex rather than as a
+// plain prefix.
+const regexPathCharacters = `[](){}*+?|\^$`
+
 func resourceKongRoute() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongRouteCreate,
-		Read:   resourceKongRouteRead,
-		Update: resourceKongRouteUpdate,
-		Delete: resourceKongRouteDelete,
+		CreateContext: resourceKongRouteCreate,
+		ReadContext:   resourceKongRouteRead,
+		UpdateContext: resourceKongRouteUpdate,
+		DeleteContext: resourceKongRouteDelete,
+
+		CustomizeDiff: customdiff.All(
+			checkAttributeVersions(routeAttributeVersions),
+			checkRouteProtocols,
+			checkRouteMatchers,
+			checkRouteStreamMatchers,
+			checkRouteRouterFlavor,
+			checkRouteConflicts,
+		),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStatePassthroughWithWorkspace,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
 
 			"name": {
 				Type:        schema.TypeString,
@@ -104,10 +116,11 @@ func resourceKongRoute() *schema.Resource {
 			},
 
 			"https_redirect_status_code": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     426,
-				Description: "The status code Kong responds with when all properties of a Route match except the protocol i.e. if the protocol of the request is HTTP instead of HTTPS",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      426,
+				ValidateFunc: validation.IntInSlice([]int{426, 301, 302, 307, 308}),
+				Description:  "The status code Kong responds with when all properties of a Route match except the protocol i.e. if the protocol of the request is HTTP instead of HTTPS",
 			},
 
 			"regex_priority": {
@@ -159,41 +172,25 @@ func resourceKongRoute() *schema.Resource {
 				Description: "A list of SNIs that match this Route when using stream routing.",
 			},
 
-			// "sources": {
-			// 	Type: schema.TypeSet,
-			// 	Elem: &schema.Resource{
-			// 		Schema: map[string]*schema.Schema{
-			// 			"ip": {
-			// 				Type:     schema.TypeString,
-			// 				Optional: true,
-			// 			},
-			// 			"port": {
-			// 				Type:     schema.TypeInt,
-			// 				Optional: true,
-			// 			},
-			// 		},
-			// 	},
-			// 	Optional:    true,
-			// 	Description: "A list of IP sources of incoming connections that match this Route when using stream routing",
-			// },
-
-			// "destinations": {
-			// 	Type: schema.TypeSet,
-			// 	Elem: &schema.Resource{
-			// 		Schema: map[string]*schema.Schema{
-			// 			"ip": {
-			// 				Type:     schema.TypeString,
-			// 				Optional: true,
-			// 			},
-			// 			"port": {
-			// 				Type:     schema.TypeInt,
-			// 				Optional: true,
-			// 			},
-			// 		},
-			// 	},
-			// 	Optional:    true,
-			// 	Description: "A list of IP destinations of incoming connections that match this Route when using stream routing",
-			// },
+			"source": routeEndpointSchema("A source IP and/or port of incoming connections that match this Route when using stream routing. Can be repeated."),
+
+			"destination": routeEndpointSchema("A destination IP and/or port of incoming connections that match this Route when using stream routing. Can be repeated."),
+
+			"expression": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: traditionalMatchers,
+				ValidateFunc:  validateRouteExpression,
+				Description:   "The expression matching requests to this Route, e.g. http.path ^= \"/foo\" && http.method == \"GET\". Requires Kong 3.0 or newer running the expressions router.",
+			},
+
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"expression"},
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The priority of the expression of this Route, the routes with the highest priority being evaluated first.",
+			},
 
 			"tags": {
 				Type:        schema.TypeList,
@@ -211,94 +208,443 @@ func resourceKongRoute() *schema.Resource {
 	}
 }
 
-func resourceKongRouteCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	info, err := meta.(*providerMeta).client.Info(ctx)
+	if err != nil {
+		return errorDiagnostics("error while reading Kong node information", err)
+	}
+
+	version, err := info.ParsedVersion()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	route := getRouteFromResourceData(d)
+	route := getRouteFromResourceData(d, version, info.Configuration.RouterFlavor)
 
-	createdRoute := new(Route)
-	response, error := sling.New().BodyJSON(route).Post("routes/").ReceiveSuccess(createdRoute)
+	createdRoute, err := c.Routes.Create(ctx, route)
+	if client.IsConflict(err) {
+		return diag.Errorf("409 Conflict - use terraform import to manage this route")
+	} else if err != nil {
+		return errorDiagnostics("error while creating Route", err)
+	}
 
-	if error != nil {
-		return fmt.Errorf("error while creating Route: " + error.Error())
+	if err := setRouteToResourceData(d, createdRoute); err != nil {
+		return diag.FromErr(err)
 	}
 
-	if response.StatusCode == http.StatusConflict {
-		return fmt.Errorf("409 Conflict - use terraform import to manage this route")
-	} else if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	return append(routePathWarnings(version, createdRoute), routeConflictWarnings(ctx, c, meta, version, createdRoute)...)
+}
+
+func resourceKongRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	route, err := c.Routes.Get(ctx, d.Id())
+	if client.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return errorDiagnostics("error while reading Route", err)
 	}
 
-	setRouteToResourceData(d, createdRoute)
+	if err := setRouteToResourceData(d, route); err != nil {
+		return diag.FromErr(err)
+	}
+
+	version, err := kongVersion(ctx, meta)
+	if err != nil {
+		return errorDiagnostics("error while reading Kong node information", err)
+	}
+
+	diags := routePathWarnings(version, route)
+
+	return append(diags, routeConflictWarnings(ctx, c, meta, version, route)...)
+}
+
+func resourceKongRouteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	info, err := meta.(*providerMeta).client.Info(ctx)
+	if err != nil {
+		return errorDiagnostics("error while reading Kong node information", err)
+	}
+
+	version, err := info.ParsedVersion()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	route := getRouteFromResourceData(d, version, info.Configuration.RouterFlavor)
+
+	updatedRoute, err := c.Routes.Update(ctx, route)
+	if err != nil {
+		return errorDiagnostics("error while updating Route", err)
+	}
+
+	if err := setRouteToResourceData(d, updatedRoute); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(routePathWarnings(version, updatedRoute), routeConflictWarnings(ctx, c, meta, version, updatedRoute)...)
+}
+
+func resourceKongRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	err := c.Routes.Delete(ctx, d.Id())
+	if err != nil {
+		return errorDiagnostics("error while deleting Route", err)
+	}
 
 	return nil
 }
 
-func resourceKongRouteRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+// routePathWarnings warns about paths whose meaning depends on the Kong
+// release: from 3.0 on only paths prefixed with ~ are regexes, and the v1
+// path_handling is deprecated.
+func routePathWarnings(version client.Version, route *client.Route) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	id := d.Id()
-	route := new(Route)
+	v3 := version.AtLeast(client.Version{Major: 3})
 
-	response, error := sling.New().Path("routes/").Get(id).ReceiveSuccess(route)
+	for i, path := range route.Paths {
+		var summary string
+		if v3 && !strings.HasPrefix(path, "~") && strings.ContainsAny(path, regexPathCharacters) {
+			summary = fmt.Sprintf("Kong %s matches path %q as a plain prefix, prefix it with ~ to match it as a regex", version, path)
+		} else if !v3 && strings.HasPrefix(path, "~") {
+			summary = fmt.Sprintf("Kong %s doesn't know the ~ prefix of regex paths and matches %q literally", version, path)
+		}
 
-	if error != nil {
-		return fmt.Errorf("error while updating Route" + error.Error())
+		if summary != "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       summary,
+				AttributePath: cty.GetAttrPath("paths").IndexInt(i),
+			})
+		}
 	}
 
-	if response.StatusCode == http.StatusNotFound {
-		d.SetId("")
+	if v3 && route.PathHandling == "v1" {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "path_handling v1 is deprecated since Kong 3.0",
+			AttributePath: cty.GetAttrPath("path_handling"),
+		})
+	}
+
+	return diags
+}
+
+// routeProtocolFamilies are the groups of protocols which can be combined on
+// a route.
+var routeProtocolFamilies = [][]string{
+	{"http", "https"},
+	{"grpc", "grpcs"},
+	{"ws", "wss"},
+	{"tcp", "tls", "udp"},
+	{"tls_passthrough"},
+}
+
+// routeRequiredMatchers maps the HTTP protocols to the attributes routes of
+// that protocol must set at least one of.
+var routeRequiredMatchers = map[string][]string{
+	"http":  {"methods", "hosts", "header", "paths"},
+	"https": {"methods", "hosts", "header", "paths", "snis"},
+	"grpc":  {"hosts", "header", "paths"},
+	"grpcs": {"hosts", "header", "paths", "snis"},
+	"ws":    {"hosts", "header", "paths"},
+	"wss":   {"hosts", "header", "paths", "snis"},
+}
+
+// pcreOnlyRegex detects the PCRE constructs Kong accepts in regexes but Go
+// takes for syntax errors: lookarounds, atomic groups, recursions,
+// backreferences, possessive quantifiers, backtracking verbs such as (*UTF)
+// and the PCRE only properties such as \p{Xan}.
+var pcreOnlyRegex = regexp.MustCompile(`\(\?(<?[=!]|>|\||[0-9+-]|R\))|\\([1-9]|[gkK])|[*+?}]\+|\(\*[A-Z_]|\\[pP]\{\^?(X|L&)`)
+
+// pcreRegexErrors are the syntax errors of Go which PCRE reports as well. The
+// other errors, such as the escapes or flags Go doesn't know (\h, \R, (?x)),
+// are left for Kong to check.
+var pcreRegexErrors = map[syntax.ErrorCode]bool{
+	syntax.ErrMissingParen:          true,
+	syntax.ErrUnexpectedParen:       true,
+	syntax.ErrMissingBracket:        true,
+	syntax.ErrTrailingBackslash:     true,
+	syntax.ErrMissingRepeatArgument: true,
+	syntax.ErrInvalidRepeatOp:       true,
+	syntax.ErrInvalidCharRange:      true,
+}
+
+// checkRouteProtocols makes sure the protocols of a route are known to Kong
+// and belong to a single family.
+func checkRouteProtocols(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("protocols") {
 		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
 	}
 
-	setRouteToResourceData(d, route)
+	family := -1
+	var first string
+	for _, protocol := range d.Get("protocols").([]interface{}) {
+		protocol, _ := protocol.(string)
+
+		f := protocolFamily(protocol)
+		if f < 0 {
+			return fmt.Errorf("unknown protocol %q, expected one of http, https, grpc, grpcs, ws, wss, tcp, tls, udp or tls_passthrough", protocol)
+		}
+
+		if family < 0 {
+			family, first = f, protocol
+		} else if f != family {
+			return fmt.Errorf("protocols %s and %s can't be combined on a route, only %s can", first, protocol, strings.Join(routeProtocolFamilies[family], ", "))
+		}
+	}
+
+	return nil
+}
+
+func protocolFamily(protocol string) int {
+	for i, family := range routeProtocolFamilies {
+		if containsString(family, protocol) {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkRouteMatchers enforces Kong's rules on the matchers of HTTP routes:
+// they need at least one of them, and their regexes must compile.
+func checkRouteMatchers(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.NewValueKnown("protocols") {
+		for _, protocol := range d.Get("protocols").([]interface{}) {
+			protocol, _ := protocol.(string)
+
+			matchers, ok := routeRequiredMatchers[protocol]
+			if !ok || isSetInDiff(d, "expression") {
+				continue
+			}
+
+			set := false
+			for _, attribute := range matchers {
+				set = set || isSetInDiff(d, attribute)
+			}
+			if !set {
+				return fmt.Errorf("routes with the %s protocol must set at least one of %s", protocol, strings.Join(matchers, ", "))
+			}
+
+			if protocol == "grpc" || protocol == "grpcs" {
+				if isSetInDiff(d, "methods") {
+					return fmt.Errorf("methods can't be set on routes with the %s protocol", protocol)
+				}
+				if d.NewValueKnown("strip_path") && d.Get("strip_path").(bool) {
+					return fmt.Errorf("strip_path must be false on routes with the %s protocol", protocol)
+				}
+			}
+		}
+	}
+
+	version, err := kongVersion(ctx, meta)
+	if err != nil {
+		return err
+	}
+	v3 := version.AtLeast(client.Version{Major: 3})
+
+	if d.NewValueKnown("paths") {
+		for _, path := range d.Get("paths").([]interface{}) {
+			path, _ := path.(string)
+
+			if !strings.HasPrefix(path, "/") && !(v3 && strings.HasPrefix(path, "~")) {
+				return fmt.Errorf("path %q must start with /", path)
+			}
+
+			regex := strings.TrimPrefix(path, "~")
+			if v3 && !strings.HasPrefix(path, "~") || !v3 && !strings.ContainsAny(path, regexPathCharacters) {
+				continue
+			}
+			if err := compileRouteRegex(regex); err != nil {
+				return fmt.Errorf("path %q is not a valid regex: %w", path, err)
+			}
+		}
+	}
+
+	for name, values := range plannedRouteHeaders(d) {
+		for _, value := range values {
+			if !strings.HasPrefix(value, "~*") {
+				continue
+			}
+			if err := compileRouteRegex("(?i)" + strings.TrimPrefix(value, "~*")); err != nil {
+				return fmt.Errorf("value %q of header %s is not a valid regex: %w", value, name, err)
+			}
+		}
+	}
 
 	return nil
 }
 
-func resourceKongRouteUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+// plannedRouteHeaders returns the known header values of the route as written
+// in the configuration, the diff losing the lists nested in header blocks.
+func plannedRouteHeaders(d *schema.ResourceDiff) map[string][]string {
+	headers := map[string][]string{}
+
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return headers
+	}
+
+	blocks := config.GetAttr("header")
+	if blocks.IsNull() || !blocks.IsKnown() {
+		return headers
+	}
+
+	for it := blocks.ElementIterator(); it.Next(); {
+		_, block := it.Element()
+		if !block.IsKnown() {
+			continue
+		}
 
-	route := getRouteFromResourceData(d)
+		name, values := block.GetAttr("name"), block.GetAttr("values")
+		if name.IsNull() || !name.IsKnown() || values.IsNull() || !values.IsKnown() {
+			continue
+		}
 
-	updatedRoute := new(Route)
+		for vit := values.ElementIterator(); vit.Next(); {
+			_, value := vit.Element()
+			if !value.IsNull() && value.IsKnown() {
+				headers[name.AsString()] = append(headers[name.AsString()], value.AsString())
+			}
+		}
+	}
 
-	response, error := sling.New().BodyJSON(route).Patch("routes/").Path(route.ID).ReceiveSuccess(updatedRoute)
+	return headers
+}
 
-	if error != nil {
-		return fmt.Errorf("error while updating Route" + error.Error())
+// compileRouteRegex compiles a regex of Kong, which uses PCRE. The regexes
+// using PCRE constructs Go doesn't support are left for Kong to check, so
+// that only the errors both report fail the plan.
+func compileRouteRegex(regex string) error {
+	if pcreOnlyRegex.MatchString(regex) {
+		return nil
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	_, err := regexp.Compile(regex)
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) && !pcreRegexErrors[syntaxErr.Code] {
+		return nil
 	}
 
-	setRouteToResourceData(d, updatedRoute)
+	return err
+}
+
+// streamProtocols are the protocols of the routes matching L4 connections
+// rather than HTTP requests.
+var streamProtocols = map[string]bool{
+	"tcp":             true,
+	"tls":             true,
+	"tls_passthrough": true,
+	"udp":             true,
+}
+
+// checkRouteStreamMatchers enforces Kong's rules on the matchers of stream
+// routes: they match on snis, sources or destinations only, which in turn
+// can't be used by HTTP routes.
+func checkRouteStreamMatchers(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, attribute := range []string{"source", "destination"} {
+		if !d.NewValueKnown(attribute) {
+			continue
+		}
+		for _, endpoint := range d.Get(attribute).(*schema.Set).List() {
+			endpoint := endpoint.(map[string]interface{})
+			if endpoint["ip"].(string) == "" && endpoint["port"].(int) == 0 {
+				return fmt.Errorf("every %s must set an ip, a port or both", attribute)
+			}
+		}
+	}
+
+	if !d.NewValueKnown("protocols") {
+		return nil
+	}
+
+	var stream, http []string
+	for _, protocol := range d.Get("protocols").([]interface{}) {
+		protocol, _ := protocol.(string)
+		if streamProtocols[protocol] {
+			stream = append(stream, protocol)
+		} else {
+			http = append(http, protocol)
+		}
+	}
+
+	if len(stream) > 0 {
+		for _, attribute := range []string{"paths", "methods", "hosts", "header"} {
+			if isSetInDiff(d, attribute) {
+				return fmt.Errorf("%s can't be set on routes with the %s protocols, which only match on snis, source and destination", attribute, strings.Join(stream, ", "))
+			}
+		}
+
+		if !isSetInDiff(d, "snis") && !isSetInDiff(d, "source") && !isSetInDiff(d, "destination") && !isSetInDiff(d, "expression") {
+			return fmt.Errorf("routes with the %s protocols must set at least one of snis, source, destination or expression", strings.Join(stream, ", "))
+		}
+	}
+
+	if len(http) > 0 {
+		for _, attribute := range []string{"source", "destination"} {
+			if isSetInDiff(d, attribute) {
+				return fmt.Errorf("%s can only be set on routes with the tcp, tls, tls_passthrough or udp protocols", attribute)
+			}
+		}
+	}
 
 	return nil
 }
 
-func resourceKongRouteDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+// checkRouteRouterFlavor matches the way the route is written with the router
+// of the Kong node: expressions need the expressions router, which in turn
+// only accepts expressions before Kong 3.7.
+func checkRouteRouterFlavor(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	info, err := meta.(*providerMeta).client.Info(ctx)
+	if err != nil {
+		return err
+	}
+
+	version, err := info.ParsedVersion()
+	if err != nil {
+		return err
+	}
+
+	// Older releases are reported by checkAttributeVersions.
+	if !version.AtLeast(client.Version{Major: 3}) {
+		return nil
+	}
 
-	id := d.Id()
+	expression := isSetInDiff(d, "expression")
+	flavor := info.Configuration.RouterFlavor
 
-	response, error := sling.New().Delete("routes/").Path(id).ReceiveSuccess(nil)
-	if error != nil {
-		return fmt.Errorf("error while deleting Route" + error.Error())
+	if expression && flavor != "expressions" {
+		return fmt.Errorf("expression requires the expressions router but Kong %s runs the %s router, see router_flavor in kong.conf", info.Version, flavor)
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	if !expression && flavor == "expressions" && !version.AtLeast(client.Version{Major: 3, Minor: 7}) {
+		return fmt.Errorf("the expressions router of Kong %s only accepts routes set with an expression, traditional matchers require Kong 3.7", info.Version)
 	}
 
 	return nil
 }
 
-func getRouteFromResourceData(d *schema.ResourceData) *Route {
-	route := &Route{
+// isSetInDiff reports whether attribute is planned to be set, unknown values
+// counting as set.
+func isSetInDiff(d *schema.ResourceDiff, attribute string) bool {
+	if !d.NewValueKnown(attribute) {
+		return true
+	}
+
+	_, ok := d.GetOk(attribute)
+	return ok
+}
+
+// getRouteFromResourceData builds the Route payload, leaving out the
+// attributes the Kong release and router flavor don't support.
+func getRouteFromResourceData(d *schema.ResourceData, version client.Version, routerFlavor string) *client.Route {
+	route := &client.Route{
 		ID:                      d.Id(),
 		Name:                    d.Get("name").(string),
 		Protocols:               helper.ConvertInterfaceArrToStrings(d.Get("protocols").([]interface{})),
@@ -311,40 +657,135 @@ func getRouteFromResourceData(d *schema.ResourceData) *Route {
 		StripPath:               d.Get("strip_path").(bool),
 		PathHandling:            d.Get("path_handling").(string),
 		PreserveHost:            d.Get("preserve_host").(bool),
-		RequestBuffering:        d.Get("request_buffering").(bool),
-		ResponseBuffering:       d.Get("response_buffering").(bool),
 		SNIs:                    helper.ConvertInterfaceArrToStrings(d.Get("snis").([]interface{})),
-		// Sources:                 helper.ConvertInterfaceArrToStrings(d.Get("sources").([]interface{})),
-		// Destinations:            helper.ConvertInterfaceArrToStrings(d.Get("destinations").([]interface{})),
-		Tags: helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
-		Service: Service{
+		Sources:                 readRouteEndpointsFromResource(d, "source"),
+		Destinations:            readRouteEndpointsFromResource(d, "destination"),
+		Expression:              d.Get("expression").(string),
+		Tags:                    helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		Service: client.Reference{
 			ID: d.Get("service").(string),
 		},
 	}
 
+	// Only the expressions router knows expression and priority. Kong merges
+	// updates into the route, an expression no longer set must be nulled to
+	// be cleared.
+	if routerFlavor == "expressions" {
+		if route.Expression == "" {
+			route.Null = append(route.Null, "expression")
+		}
+		priority := d.Get("priority").(int)
+		route.Priority = &priority
+	}
+	if routeAttributeVersions.supports(version, "request_buffering") {
+		requestBuffering := d.Get("request_buffering").(bool)
+		route.RequestBuffering = &requestBuffering
+	}
+	if routeAttributeVersions.supports(version, "response_buffering") {
+		responseBuffering := d.Get("response_buffering").(bool)
+		route.ResponseBuffering = &responseBuffering
+	}
+
 	return route
 }
 
-func setRouteToResourceData(d *schema.ResourceData, route *Route) {
+func setRouteToResourceData(d *schema.ResourceData, route *client.Route) error {
 	d.SetId(route.ID)
 	d.Set("name", route.Name)
 	d.Set("protocols", route.Protocols)
 	d.Set("methods", route.Methods)
 	d.Set("hosts", route.Hosts)
 	d.Set("paths", route.Paths)
-	d.Set("headers", route.Headers)
+	if err := d.Set("header", flattenRouteHeaders(route.Headers)); err != nil {
+		return err
+	}
 	d.Set("https_redirect_status_code", route.HttpsRedirectStatusCode)
 	d.Set("regex_priority", route.RegexPriority)
 	d.Set("strip_path", route.StripPath)
 	d.Set("path_handling", route.PathHandling)
 	d.Set("preserve_host", route.PreserveHost)
-	d.Set("request_buffering", route.RequestBuffering)
-	d.Set("response_buffering", route.ResponseBuffering)
+	if route.RequestBuffering != nil {
+		d.Set("request_buffering", *route.RequestBuffering)
+	}
+	if route.ResponseBuffering != nil {
+		d.Set("response_buffering", *route.ResponseBuffering)
+	}
 	d.Set("snis", route.SNIs)
-	// d.Set("sources", route.Sources)
-	// d.Set("destinations", route.Destinations)
+	if err := d.Set("source", flattenRouteEndpoints(route.Sources)); err != nil {
+		return err
+	}
+	if err := d.Set("destination", flattenRouteEndpoints(route.Destinations)); err != nil {
+		return err
+	}
+	d.Set("expression", route.Expression)
+	if route.Priority != nil {
+		d.Set("priority", *route.Priority)
+	}
 	d.Set("tags", route.Tags)
 	d.Set("service", route.Service.ID)
+
+	return nil
+}
+
+func routeEndpointSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ip": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR),
+					Description:  "An IP address or a CIDR range.",
+				},
+				"port": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IsPortNumber,
+				},
+			},
+		},
+		Description: description,
+	}
+}
+
+func readRouteEndpointsFromResource(d *schema.ResourceData, key string) []*client.RouteEndpoint {
+	var endpoints []*client.RouteEndpoint
+	for _, item := range d.Get(key).(*schema.Set).List() {
+		m := item.(map[string]interface{})
+		endpoints = append(endpoints, &client.RouteEndpoint{
+			IP:   m["ip"].(string),
+			Port: m["port"].(int),
+		})
+	}
+
+	return endpoints
+}
+
+func flattenRouteEndpoints(endpoints []*client.RouteEndpoint) []interface{} {
+	flattened := make([]interface{}, len(endpoints))
+	for i, endpoint := range endpoints {
+		flattened[i] = map[string]interface{}{
+			"ip":   endpoint.IP,
+			"port": endpoint.Port,
+		}
+	}
+
+	return flattened
+}
+
+// flattenRouteHeaders returns the header blocks matching the given headers.
+func flattenRouteHeaders(headers map[string][]string) []interface{} {
+	flattened := make([]interface{}, 0, len(headers))
+	for name, values := range headers {
+		flattened = append(flattened, map[string]interface{}{
+			"name":   name,
+			"values": values,
+		})
+	}
+
+	return flattened
 }
 
 func readMapStringArrayFromResource(d *schema.ResourceData, key string) map[string][]string {
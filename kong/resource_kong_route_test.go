@@ -0,0 +1,94 @@
+package kong
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCompileRouteRegex(t *testing.T) {
+	tests := []struct {
+		regex string
+		valid bool
+	}{
+		{`/users/\d+`, true},
+		{`/(?<id>\d+)/items`, true},
+		{`/files/.*\.(png|jpg)$`, true},
+		// PCRE only constructs are left for Kong to check.
+		{`/(?!admin)`, true},
+		{`/(?<=v1)/users`, true},
+		{`/(\w+)/\1`, true},
+		{`/\d++`, true},
+		{`/(?>a|ab)c`, true},
+		{`/users\h\d+`, true},
+		{`/lines\R`, true},
+		{`/\Q.*+\E`, true},
+		{`(*UTF)/caf\x{e9}`, true},
+		{`(*UCP)/\w+`, true},
+		{`(?x) /users / \d+`, true},
+		{`/(?|(a)|(b))`, true},
+		{`/\p{Xan}+`, true},
+		{`/(?#comment)users`, true},
+		{`/users/\d{1,2000}`, true},
+
+		{`/users/(\d+`, false},
+		{`/users/[a-z`, false},
+		{`/users/*+`, true},
+		{`/users/**`, false},
+		{`/users/\`, false},
+		{`/users)`, false},
+		{`/users/[z-a]`, false},
+	}
+
+	for _, test := range tests {
+		if err := compileRouteRegex(test.regex); (err == nil) != test.valid {
+			t.Errorf("compileRouteRegex(%q) = %v, want valid %v", test.regex, err, test.valid)
+		}
+	}
+}
+
+func TestProtocolFamily(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"http", "https", true},
+		{"grpc", "grpcs", true},
+		{"ws", "wss", true},
+		{"tcp", "tls", true},
+		{"tcp", "udp", true},
+		{"http", "grpc", false},
+		{"https", "tls", false},
+		{"tls", "tls_passthrough", false},
+	}
+
+	for _, test := range tests {
+		if same := protocolFamily(test.a) == protocolFamily(test.b); same != test.same {
+			t.Errorf("%s and %s in the same family = %v, want %v", test.a, test.b, same, test.same)
+		}
+	}
+
+	if family := protocolFamily("ftp"); family != -1 {
+		t.Errorf("protocolFamily(ftp) = %d, want -1", family)
+	}
+}
+
+func TestSetRouteToResourceDataHeaders(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceKongRoute().Schema, map[string]interface{}{})
+
+	route := &client.Route{
+		ID:        "r1",
+		Protocols: []string{"http", "https"},
+		Headers:   map[string][]string{"x-version": {"2", "3"}, "x-tenant": {"acme"}},
+		Service:   client.Reference{ID: "s1"},
+	}
+	if err := setRouteToResourceData(d, route); err != nil {
+		t.Fatal(err)
+	}
+
+	if headers := readMapStringArrayFromResource(d, "header"); !reflect.DeepEqual(headers, route.Headers) {
+		t.Errorf("header read back as %v, want %v", headers, route.Headers)
+	}
+}
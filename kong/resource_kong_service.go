@@ -1,46 +1,40 @@
 package kong
 
 import (
-	"fmt"
-	"net/http"
+	"context"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-// Service : Kong Service request object structure
-type Service struct {
-	ID                string      `json:"id,omitempty"`
-	Name              string      `json:"name,omitempty"`
-	Retries           int         `json:"retries,omitempty"`
-	Protocol          string      `json:"protocol,omitempty"`
-	Host              string      `json:"host,omitempty"`
-	Port              int         `json:"port,omitempty"`
-	Path              string      `json:"path,omitempty"`
-	ConnectTimeout    int         `json:"connect_timeout,omitempty"`
-	WriteTimeout      int         `json:"write_timeout,omitempty"`
-	ReadTimeout       int         `json:"read_timeout,omitempty"`
-	Tags              []string    `json:"tags"`
-	ClientCertificate Certificate `json:"-"`                          // TO DO: add if statement which assign value only if Protocol is HTTPS
-	TlsVerify         bool        `json:"tls_verify,omitempty"`       //
-	TlsVerifyDepth    int         `json:"tls_verify_depth,omitempty"` //
-	CACertificates    []string    `json:"-"`                          //
-	Enabled           bool        `json:"enabled"`
+// serviceAttributeVersions lists the attributes which appeared after
+// minimumKongVersion.
+var serviceAttributeVersions = attributeVersions{
+	"tls_verify":       {Major: 2, Minor: 3},
+	"tls_verify_depth": {Major: 2, Minor: 3},
+	"ca_certificates":  {Major: 2, Minor: 3},
+	"enabled":          {Major: 2, Minor: 7},
 }
 
 func resourceKongService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongServiceCreate,
-		Read:   resourceKongServiceRead,
-		Update: resourceKongServiceUpdate,
-		Delete: resourceKongServiceDelete,
+		CreateContext: resourceKongServiceCreate,
+		ReadContext:   resourceKongServiceRead,
+		UpdateContext: resourceKongServiceUpdate,
+		DeleteContext: resourceKongServiceDelete,
+
+		CustomizeDiff: checkAttributeVersions(serviceAttributeVersions),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStatePassthroughWithWorkspace,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
 			"name": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -113,31 +107,31 @@ func resourceKongService() *schema.Resource {
 			"client_certificate": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Description:  "Certificate to be used as client certificate while TLS handshaking to the upstream server",
-				RequiredWith: []string{"protocol"},
-				Default:      nil,
+				ValidateFunc: validation.IsUUID,
+				Description:  "The id of the Certificate presented as client certificate while TLS handshaking to the upstream server",
 			},
 
 			"tls_verify": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Whether to enable verification of upstream server TLS certificate. If set to null, then the Nginx default is respected",
-				Default:     nil,
+				Description: "Whether to enable verification of upstream server TLS certificate. If unset, then the Nginx default is respected",
 			},
 
 			"tls_verify_depth": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "Maximum depth of chain while verifying Upstream servers TLS certificate. If set to null, then the Nginx default is respected",
-				Default:     nil,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 64),
+				Description:  "Maximum depth of chain while verifying Upstream servers TLS certificate. If unset, then the Nginx default is respected",
 			},
 
 			"ca_certificates": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
 				Optional:    true,
 				Description: "Array of CA Certificate object UUIDs that are used to build the trust store while verifying upstream servers TLS certificate",
-				Default:     nil,
 			},
 
 			"enabled": {
@@ -150,22 +144,21 @@ func resourceKongService() *schema.Resource {
 	}
 }
 
-func resourceKongServiceCreate(d *schema.ResourceData, meta interface{}) error {
-	s := meta.(*sling.Sling)
-
-	service := getServiceFromResourceData(d)
-
-	createdService := new(Service)
-	response, e := s.New().BodyJSON(service).Post("services/").ReceiveSuccess(createdService)
+func resourceKongServiceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	if e != nil {
-		return fmt.Errorf("error while creating Service: " + e.Error())
+	version, err := kongVersion(ctx, meta)
+	if err != nil {
+		return errorDiagnostics("error while reading Kong node information", err)
 	}
 
-	if response.StatusCode == http.StatusConflict {
-		return fmt.Errorf("409 Conflict - use terraform import to manage this service")
-	} else if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	service := getServiceFromResourceData(d, version)
+
+	createdService, err := c.Services.Create(ctx, service)
+	if client.IsConflict(err) {
+		return diag.Errorf("409 Conflict - use terraform import to manage this service")
+	} else if err != nil {
+		return errorDiagnostics("error while creating Service", err)
 	}
 
 	setServiceToResourceData(d, createdService)
@@ -173,23 +166,15 @@ func resourceKongServiceCreate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongServiceRead(d *schema.ResourceData, meta interface{}) error {
-	s := meta.(*sling.Sling)
-
-	id := d.Id()
-	service := new(Service)
-
-	response, e := s.New().Path("services/").Get(id).ReceiveSuccess(service)
+func resourceKongServiceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	if e != nil {
-		return fmt.Errorf("error while updating Service" + e.Error())
-	}
-
-	if response.StatusCode == http.StatusNotFound {
+	service, err := c.Services.Get(ctx, d.Id())
+	if client.IsNotFound(err) {
 		d.SetId("")
 		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	} else if err != nil {
+		return errorDiagnostics("error while reading Service", err)
 	}
 
 	setServiceToResourceData(d, service)
@@ -197,21 +182,19 @@ func resourceKongServiceRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongServiceUpdate(d *schema.ResourceData, meta interface{}) error {
-	s := meta.(*sling.Sling)
-
-	service := getServiceFromResourceData(d)
+func resourceKongServiceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	updatedService := new(Service)
-
-	response, e := s.New().BodyJSON(service).Patch("services/").Path(service.ID).ReceiveSuccess(updatedService)
-
-	if e != nil {
-		return fmt.Errorf("error while updating Service" + e.Error())
+	version, err := kongVersion(ctx, meta)
+	if err != nil {
+		return errorDiagnostics("error while reading Kong node information", err)
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	service := getServiceFromResourceData(d, version)
+
+	updatedService, err := c.Services.Update(ctx, service)
+	if err != nil {
+		return errorDiagnostics("error while updating Service", err)
 	}
 
 	setServiceToResourceData(d, updatedService)
@@ -219,25 +202,21 @@ func resourceKongServiceUpdate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongServiceDelete(d *schema.ResourceData, meta interface{}) error {
-	s := meta.(*sling.Sling)
-
-	id := d.Id()
-
-	response, e := s.New().Delete("services/").Path(id).ReceiveSuccess(nil)
-	if e != nil {
-		return fmt.Errorf("error while deleting Service" + e.Error())
-	}
+func resourceKongServiceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unexpected status code received: " + response.Status)
+	err := c.Services.Delete(ctx, d.Id())
+	if err != nil {
+		return errorDiagnostics("error while deleting Service", err)
 	}
 
 	return nil
 }
 
-func getServiceFromResourceData(d *schema.ResourceData) *Service {
-	service := &Service{
+// getServiceFromResourceData builds the Service payload, leaving out the
+// attributes the Kong release doesn't support.
+func getServiceFromResourceData(d *schema.ResourceData, version client.Version) *client.Service {
+	service := &client.Service{
 		ID:             d.Id(),
 		Name:           d.Get("name").(string),
 		Retries:        d.Get("retries").(int),
@@ -249,19 +228,51 @@ func getServiceFromResourceData(d *schema.ResourceData) *Service {
 		WriteTimeout:   d.Get("write_timeout").(int),
 		ReadTimeout:    d.Get("read_timeout").(int),
 		Tags:           helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
-		ClientCertificate: Certificate{
-			ID: d.Get("client_certificate").(string),
-		},
-		TlsVerify:      d.Get("tls_verify").(bool),
-		TlsVerifyDepth: d.Get("tls_verify_depth").(int),
-		CACertificates: helper.ConvertInterfaceArrToStrings(d.Get("ca_certificates").([]interface{})),
-		Enabled:        d.Get("enabled").(bool),
+	}
+
+	if serviceAttributeVersions.supports(version, "enabled") {
+		enabled := d.Get("enabled").(bool)
+		service.Enabled = &enabled
+	}
+
+	// Unset relations and TLS settings are sent as null, for Kong to clear
+	// them and use the Nginx defaults, but only to the releases knowing them.
+	if id := d.Get("client_certificate").(string); id != "" {
+		service.ClientCertificate = &client.Reference{ID: id}
+	} else {
+		service.Null = append(service.Null, "client_certificate")
+	}
+
+	if serviceAttributeVersions.supports(version, "tls_verify") {
+		if isSetInConfig(d, "tls_verify") {
+			tlsVerify := d.Get("tls_verify").(bool)
+			service.TlsVerify = &tlsVerify
+		} else {
+			service.Null = append(service.Null, "tls_verify")
+		}
+	}
+
+	if serviceAttributeVersions.supports(version, "tls_verify_depth") {
+		if isSetInConfig(d, "tls_verify_depth") {
+			tlsVerifyDepth := d.Get("tls_verify_depth").(int)
+			service.TlsVerifyDepth = &tlsVerifyDepth
+		} else {
+			service.Null = append(service.Null, "tls_verify_depth")
+		}
+	}
+
+	if serviceAttributeVersions.supports(version, "ca_certificates") {
+		if caCertificates := d.Get("ca_certificates").([]interface{}); len(caCertificates) > 0 {
+			service.CACertificates = helper.ConvertInterfaceArrToStrings(caCertificates)
+		} else {
+			service.Null = append(service.Null, "ca_certificates")
+		}
 	}
 
 	return service
 }
 
-func setServiceToResourceData(d *schema.ResourceData, service *Service) {
+func setServiceToResourceData(d *schema.ResourceData, service *client.Service) {
 	d.SetId(service.ID)
 	_ = d.Set("name", service.Name)
 	_ = d.Set("retries", service.Retries)
@@ -273,9 +284,38 @@ func setServiceToResourceData(d *schema.ResourceData, service *Service) {
 	_ = d.Set("write_timeout", service.WriteTimeout)
 	_ = d.Set("read_timeout", service.ReadTimeout)
 	_ = d.Set("tags", service.Tags)
-	_ = d.Set("client_certificate", service.ClientCertificate)
-	_ = d.Set("tls_verify", service.TlsVerify)
-	_ = d.Set("tls_verify_depth", service.TlsVerifyDepth)
 	_ = d.Set("ca_certificates", service.CACertificates)
-	_ = d.Set("enabled", service.Enabled)
+	if service.Enabled != nil {
+		_ = d.Set("enabled", *service.Enabled)
+	}
+
+	if service.ClientCertificate != nil {
+		_ = d.Set("client_certificate", service.ClientCertificate.ID)
+	} else {
+		_ = d.Set("client_certificate", nil)
+	}
+
+	if service.TlsVerify != nil {
+		_ = d.Set("tls_verify", *service.TlsVerify)
+	} else {
+		_ = d.Set("tls_verify", nil)
+	}
+
+	if service.TlsVerifyDepth != nil {
+		_ = d.Set("tls_verify_depth", *service.TlsVerifyDepth)
+	} else {
+		_ = d.Set("tls_verify_depth", nil)
+	}
+}
+
+// isSetInConfig reports whether the configuration sets attribute, which tells
+// an unset attribute from one set to its zero value.
+func isSetInConfig(d *schema.ResourceData, attribute string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.Type().HasAttribute(attribute) {
+		_, ok := d.GetOk(attribute)
+		return ok
+	}
+
+	return !config.GetAttr(attribute).IsNull()
 }
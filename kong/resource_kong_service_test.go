@@ -0,0 +1,59 @@
+package kong
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGetServiceFromResourceDataVersions(t *testing.T) {
+	tests := []struct {
+		version client.Version
+		config  map[string]interface{}
+		sent    map[string]string
+	}{
+		{
+			version: client.Version{Major: 2, Minor: 2},
+			config:  map[string]interface{}{"host": "backend"},
+			sent:    map[string]string{"client_certificate": "null", "tls_verify": "", "tls_verify_depth": "", "ca_certificates": "", "enabled": ""},
+		},
+		{
+			version: client.Version{Major: 2, Minor: 8},
+			config:  map[string]interface{}{"host": "backend"},
+			sent:    map[string]string{"client_certificate": "null", "tls_verify": "null", "tls_verify_depth": "null", "ca_certificates": "null", "enabled": "true"},
+		},
+		{
+			version: client.Version{Major: 2, Minor: 8},
+			config: map[string]interface{}{
+				"host":               "backend",
+				"client_certificate": "c1",
+				"tls_verify":         true,
+				"tls_verify_depth":   2,
+				"ca_certificates":    []interface{}{"ca1"},
+			},
+			sent: map[string]string{"client_certificate": `{"id":"c1"}`, "tls_verify": "true", "tls_verify_depth": "2", "ca_certificates": `["ca1"]`, "enabled": "true"},
+		},
+	}
+
+	for _, test := range tests {
+		d := schema.TestResourceDataRaw(t, resourceKongService().Schema, test.config)
+
+		b, err := json.Marshal(getServiceFromResourceData(d, test.version))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(b, &fields); err != nil {
+			t.Fatal(err)
+		}
+
+		for field, want := range test.sent {
+			if got := string(fields[field]); got != want {
+				t.Errorf("Kong %s with %v: %s sent as %q, want %q", test.version, test.config, field, got, want)
+			}
+		}
+	}
+}
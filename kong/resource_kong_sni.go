@@ -1,28 +1,24 @@
 package kong
 
 import (
-	"fmt"
-	"net/http"
+	"context"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-type SNI struct {
-	Name             string      `json:"name,omitempty"`
-	SSLCertificateID Certificate `json:"certificate,omitempty"`
-	Tags             []string    `json:"tags"`
-}
-
 func resourceKongSNI() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongSNICreate,
-		Read:   resourceKongSNIRead,
-		Update: resourceKongSNIUpdate,
-		Delete: resourceKongSNIDelete,
+		CreateContext: resourceKongSNICreate,
+		ReadContext:   resourceKongSNIRead,
+		UpdateContext: resourceKongSNIUpdate,
+		DeleteContext: resourceKongSNIDelete,
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -44,20 +40,14 @@ func resourceKongSNI() *schema.Resource {
 	}
 }
 
-func resourceKongSNICreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongSNICreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	sni := getSNIFromResourceData(d)
 
-	createdSNI := getSNIFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(sni).Post("snis/").ReceiveSuccess(createdSNI)
-	if error != nil {
-		return fmt.Errorf("error while creating SNI")
-	}
-
-	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+	createdSNI, err := c.SNIs.Create(ctx, sni)
+	if err != nil {
+		return errorDiagnostics("error while creating SNI", err)
 	}
 
 	setSNIToResourceData(d, createdSNI)
@@ -65,21 +55,15 @@ func resourceKongSNICreate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongSNIRead(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
-
-	sni := getSNIFromResourceData(d)
+func resourceKongSNIRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	response, error := sling.New().Path("snis/").Get(sni.Name).ReceiveSuccess(sni)
-	if error != nil {
-		return fmt.Errorf("error while updating SNI")
-	}
-
-	if response.StatusCode == http.StatusNotFound {
+	sni, err := c.SNIs.Get(ctx, d.Id())
+	if client.IsNotFound(err) {
 		d.SetId("")
 		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	} else if err != nil {
+		return errorDiagnostics("error while reading SNI", err)
 	}
 
 	setSNIToResourceData(d, sni)
@@ -87,20 +71,14 @@ func resourceKongSNIRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongSNIUpdate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongSNIUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	sni := getSNIFromResourceData(d)
 
-	updatedSNI := getSNIFromResourceData(d)
-
-	response, error := sling.New().BodyJSON(sni).Path("snis/").Patch(sni.Name).ReceiveSuccess(updatedSNI)
-	if error != nil {
-		return fmt.Errorf("error while updating SNI")
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	updatedSNI, err := c.SNIs.Update(ctx, sni)
+	if err != nil {
+		return errorDiagnostics("error while updating SNI", err)
 	}
 
 	setSNIToResourceData(d, updatedSNI)
@@ -108,27 +86,21 @@ func resourceKongSNIUpdate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongSNIDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
-
-	sni := getSNIFromResourceData(d)
-
-	response, error := sling.New().Path("snis/").Delete(sni.Name).ReceiveSuccess(nil)
-	if error != nil {
-		return fmt.Errorf("error while deleting SNI")
-	}
+func resourceKongSNIDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	err := c.SNIs.Delete(ctx, d.Id())
+	if err != nil {
+		return errorDiagnostics("error while deleting SNI", err)
 	}
 
 	return nil
 }
 
-func getSNIFromResourceData(d *schema.ResourceData) *SNI {
-	sni := &SNI{
+func getSNIFromResourceData(d *schema.ResourceData) *client.SNI {
+	sni := &client.SNI{
 		Name: d.Get("name").(string),
-		SSLCertificateID: Certificate{
+		SSLCertificateID: client.Certificate{
 			ID: d.Get("certificate").(string),
 		},
 		Tags: helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
@@ -137,7 +109,7 @@ func getSNIFromResourceData(d *schema.ResourceData) *SNI {
 	return sni
 }
 
-func setSNIToResourceData(d *schema.ResourceData, sni *SNI) {
+func setSNIToResourceData(d *schema.ResourceData, sni *client.SNI) {
 	d.SetId(sni.Name)
 	d.Set("name", sni.Name)
 	d.Set("certificate", sni.SSLCertificateID)
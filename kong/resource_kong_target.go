@@ -1,30 +1,43 @@
 package kong
 
 import (
+	"context"
 	"fmt"
-	"net/http"
 	"strings"
+	"time"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-type Target struct {
-	ID       string   `json:"id,omitempty"`
-	Upstream string   `json:"-"`
-	Target   string   `json:"target,omitempty"`
-	Weight   int      `json:"weight,omitempty"`
-	Tags     []string `json:"tags"`
-}
+// targetDeleteTimeout is the default delete timeout, which must leave time to
+// drain the target.
+const targetDeleteTimeout = 10 * time.Minute
 
 func resourceKongTarget() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongTargetCreate,
-		Read:   resourceKongTargetRead,
-		Delete: resourceKongTargetDelete,
+		CreateContext: resourceKongTargetCreate,
+		ReadContext:   resourceKongTargetRead,
+		UpdateContext: resourceKongTargetUpdate,
+		DeleteContext: resourceKongTargetDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportTarget,
+		},
+
+		CustomizeDiff: checkTargetDrainPeriod,
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(targetDeleteTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
 			"upstream": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -43,67 +56,193 @@ func resourceKongTarget() *schema.Resource {
 			},
 
 			"weight": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(0, 65535),
+				Description:  "The weight this target gets within the upstream loadbalancer (0-65535). A weight of 0 takes the target out of the balancer. Defaults to 100.",
 			},
 
 			"tags": {
 				Type:        schema.TypeList,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
-				ForceNew:    true,
 				Description: "An optional set of strings associated with the Service for grouping and filtering.",
 			},
+
+			"drain": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Drain the target before deleting it: its weight is set to 0 so that it stops receiving new requests, then the provider waits until the active connections of the Kong node have dropped, or for drain_period at most, before deleting it.",
+			},
+
+			"drain_period": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The longest time in seconds to wait between setting the weight of a drained target to 0 and deleting it. The wait ends earlier once the Kong node reports no active connection other than the provider's. The Admin API doesn't expose the connections of a single target, so on a busy node the full period is waited. It must be shorter than the delete timeout. Defaults to 30.",
+			},
 		},
 	}
 }
 
-func resourceKongTargetCreate(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongTargetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	target := getTargetFromResourceData(d)
 
-	createdTarget := getTargetFromResourceData(d)
-
-	response, error := sling.New().Path("upstreams/").Path(target.Upstream + "/").BodyJSON(target).Post("targets/").ReceiveSuccess(createdTarget)
-	if error != nil {
-		return fmt.Errorf("error while creating target")
+	createdTarget, err := c.Upstreams.Targets(target.Upstream).Create(ctx, target)
+	if err != nil {
+		return errorDiagnostics("error while creating target", err)
 	}
 
-	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+	setTargetToResourceData(d, createdTarget)
+
+	return nil
+}
+
+func resourceKongTargetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	target, err := c.Upstreams.Targets(d.Get("upstream").(string)).Get(ctx, d.Id())
+	if client.IsNotFound(err) {
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return errorDiagnostics("error while reading target", err)
 	}
 
-	setTargetToResourceData(d, createdTarget)
+	setTargetToResourceData(d, target)
 
 	return nil
 }
 
-func resourceKongTargetRead(d *schema.ResourceData, meta interface{}) error {
-	// Targets can't be read, so we ignore the read operation.
+func resourceKongTargetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
+
+	target := getTargetFromResourceData(d)
+
+	updatedTarget, err := c.Upstreams.Targets(target.Upstream).Update(ctx, target)
+	if err != nil {
+		return errorDiagnostics("error while updating target", err)
+	}
+
+	setTargetToResourceData(d, updatedTarget)
+
 	return nil
 }
 
-func resourceKongTargetDelete(d *schema.ResourceData, meta interface{}) error {
-	sling := meta.(*sling.Sling)
+func resourceKongTargetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
 	target := getTargetFromResourceData(d)
 
-	response, error := sling.New().Path("upstreams/").Path(target.Upstream + "/").Path("targets/").Delete(target.ID).ReceiveSuccess(nil)
-	if error != nil {
-		return fmt.Errorf("error while deleting target")
+	if d.Get("drain").(bool) {
+		if diags := drainTarget(ctx, d, c, target); diags != nil {
+			return diags
+		}
+	}
+
+	err := c.Upstreams.Targets(target.Upstream).Delete(ctx, target.ID)
+	if err != nil {
+		return errorDiagnostics("error while deleting target", err)
+	}
+
+	return nil
+}
+
+// targetDrainPollInterval is how often the node status is polled while a
+// target drains.
+var targetDrainPollInterval = 2 * time.Second
+
+// drainTarget takes the target out of the balancer, then waits until the
+// connections of the node have dropped or drain_period has elapsed before it
+// gets deleted. The Admin API doesn't expose the connections of a target, so
+// the node counters stand for them: they have dropped once the only active
+// connection left is the one polling the status.
+func drainTarget(ctx context.Context, d *schema.ResourceData, c *client.Client, target *client.Target) diag.Diagnostics {
+	period := time.Duration(d.Get("drain_period").(int)) * time.Second
+
+	if target.Weight != 0 {
+		drained := *target
+		drained.Weight = 0
+
+		_, err := c.Upstreams.Targets(target.Upstream).Update(ctx, &drained)
+		if client.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errorDiagnostics("error while draining target", err)
+		}
+	}
+
+	fields := map[string]interface{}{
+		"target":   target.Target,
+		"upstream": target.Upstream,
+		"period":   period.String(),
+	}
+	tflog.Info(ctx, "Draining Kong target", fields)
+
+	deadline := time.NewTimer(period)
+	defer deadline.Stop()
+	poll := time.NewTicker(targetDrainPollInterval)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-deadline.C:
+			tflog.Info(ctx, "Kong target drain period elapsed", fields)
+			return nil
+		case <-poll.C:
+			status, err := c.Status(ctx)
+			if err != nil {
+				// The status endpoint may be disabled or unreachable, in which
+				// case only the drain period is left.
+				tflog.Debug(ctx, "Unable to read the Kong node status while draining", fields, map[string]interface{}{"error": err.Error()})
+				continue
+			}
+			if status.Server.ConnectionsActive <= 1 {
+				tflog.Info(ctx, "Kong target drained", fields)
+				return nil
+			}
+		case <-ctx.Done():
+			return diag.Errorf("error while draining target: %s", ctx.Err())
+		}
+	}
+}
+
+// checkTargetDrainPeriod fails the plan when the target would be deleted by
+// the delete timeout before the end of its drain period.
+func checkTargetDrainPeriod(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("drain").(bool) || !d.NewValueKnown("drain_period") {
+		return nil
+	}
+
+	period := time.Duration(d.Get("drain_period").(int)) * time.Second
+
+	timeout := targetDeleteTimeout
+	if config := d.GetRawConfig(); !config.IsNull() && config.IsKnown() && config.Type().HasAttribute("timeouts") {
+		if timeouts := config.GetAttr("timeouts"); !timeouts.IsNull() && timeouts.IsKnown() {
+			if value := timeouts.GetAttr("delete"); !value.IsNull() && value.IsKnown() {
+				configured, err := time.ParseDuration(value.AsString())
+				if err != nil {
+					return fmt.Errorf("invalid delete timeout: %w", err)
+				}
+				timeout = configured
+			}
+		}
 	}
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	if period >= timeout {
+		return fmt.Errorf("the drain period of %s must be shorter than the delete timeout of %s", period, timeout)
 	}
 
 	return nil
 }
 
-func getTargetFromResourceData(d *schema.ResourceData) *Target {
-	target := &Target{
+func getTargetFromResourceData(d *schema.ResourceData) *client.Target {
+	target := &client.Target{
 		ID:       d.Id(),
 		Target:   d.Get("target").(string),
 		Upstream: d.Get("upstream").(string),
@@ -114,7 +253,7 @@ func getTargetFromResourceData(d *schema.ResourceData) *Target {
 	return target
 }
 
-func setTargetToResourceData(d *schema.ResourceData, target *Target) {
+func setTargetToResourceData(d *schema.ResourceData, target *client.Target) {
 	d.SetId(target.ID)
 	d.Set("target", target.Target)
 	d.Set("upstream", target.Upstream)
@@ -0,0 +1,77 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDrainTarget(t *testing.T) {
+	defer func(interval time.Duration) { targetDrainPollInterval = interval }(targetDrainPollInterval)
+	targetDrainPollInterval = 10 * time.Millisecond
+
+	tests := []struct {
+		name   string
+		status func(polls int32) (int, int)
+		early  bool
+	}{
+		{"connections dropping", func(polls int32) (int, int) { return http.StatusOK, 4 - int(polls) }, true},
+		{"busy node", func(int32) (int, int) { return http.StatusOK, 12 }, false},
+		{"status unavailable", func(int32) (int, int) { return http.StatusNotFound, 0 }, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var polls, drained int32
+			kong := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/status":
+					code, active := test.status(atomic.AddInt32(&polls, 1))
+					w.WriteHeader(code)
+					fmt.Fprintf(w, `{"server":{"connections_active":%d}}`, active)
+				case r.Method == http.MethodPatch:
+					atomic.AddInt32(&drained, 1)
+					fmt.Fprint(w, `{"id":"t1","target":"10.0.0.1:8000","weight":0}`)
+				default:
+					fmt.Fprint(w, `{"version":"3.4.0"}`)
+				}
+			}))
+			defer kong.Close()
+
+			meta, diags := providerConfigure(context.Background(), schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+				"address": kong.URL,
+			}))
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceKongTarget().Schema, map[string]interface{}{
+				"upstream":     "backend",
+				"target":       "10.0.0.1:8000",
+				"drain":        true,
+				"drain_period": 1,
+			})
+			target := &client.Target{ID: "t1", Target: "10.0.0.1:8000", Upstream: "backend", Weight: 100}
+
+			start := time.Now()
+			if diags := drainTarget(context.Background(), d, meta.(*providerMeta).client, target); diags.HasError() {
+				t.Fatal(diags)
+			}
+			elapsed := time.Since(start)
+
+			if drained != 1 {
+				t.Errorf("weight set to 0 %d times, want once", drained)
+			}
+			if early := elapsed < time.Second; early != test.early {
+				t.Errorf("drained in %s, want before the drain period %v", elapsed, test.early)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceKongTypedPlugin returns the resource managing the Kong plugin name
+// through a typed config block instead of config_json. The attributes of
+// config carry the names of the plugin configuration fields, nested records
+// being single element blocks, so that the configuration sent to Kong is
+// derived from the schema. Within config, an attribute left out of the
+// configuration is sent as its default when it has one, left for Kong to
+// default when it is computed and cleared otherwise. The attributes of config
+// listed in versions, as config.<field>, are only sent to the Kong releases
+// supporting them.
+func resourceKongTypedPlugin(name string, config map[string]*schema.Schema, versions attributeVersions) *schema.Resource {
+	return &schema.Resource{
+		CreateContext: typedPluginCreate(name, config, versions),
+		ReadContext:   typedPluginRead(name, config),
+		UpdateContext: typedPluginUpdate(name, config, versions),
+		DeleteContext: resourceKongPluginDelete,
+
+		CustomizeDiff: customdiff.Sequence(
+			checkAttributeVersions(versions),
+			validateTypedPluginConfig(name, config),
+		),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importStatePassthroughWithWorkspace,
+		},
+
+		Schema: mergeSchemas(pluginScopeSchema(), map[string]*schema.Schema{
+			"config": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem:        &schema.Resource{Schema: config},
+				Description: "The configuration of the " + name + " plugin. Kong defaults apply when omitted.",
+			},
+		}),
+	}
+}
+
+func typedPluginCreate(name string, config map[string]*schema.Schema, versions attributeVersions) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		c := clientFor(d, meta)
+
+		version, err := kongVersion(ctx, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		plugin := buildTypedPlugin(d, name, config, versions, version)
+
+		createdPlugin, err := c.Plugins.Create(ctx, plugin)
+		if client.IsConflict(err) {
+			return diag.Errorf("409 Conflict - use terraform import to manage this plugin")
+		} else if err != nil {
+			return typedPluginDiagnostics("error while creating "+name+" plugin", err, config)
+		}
+
+		return diag.FromErr(setTypedPluginToResourceData(d, createdPlugin, config))
+	}
+}
+
+func typedPluginRead(name string, config map[string]*schema.Schema) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		c := clientFor(d, meta)
+
+		plugin, err := c.Plugins.Get(ctx, d.Id())
+		if client.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		} else if err != nil {
+			return errorDiagnostics("error while reading "+name+" plugin", err)
+		}
+
+		if plugin.Name != name {
+			return diag.Errorf("plugin %s is a %s plugin, not a %s plugin", plugin.ID, plugin.Name, name)
+		}
+
+		return diag.FromErr(setTypedPluginToResourceData(d, plugin, config))
+	}
+}
+
+func typedPluginUpdate(name string, config map[string]*schema.Schema, versions attributeVersions) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		c := clientFor(d, meta)
+
+		version, err := kongVersion(ctx, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		plugin := buildTypedPlugin(d, name, config, versions, version)
+
+		updatedPlugin, err := c.Plugins.Update(ctx, plugin)
+		if err != nil {
+			return typedPluginDiagnostics("error while updating "+name+" plugin", err, config)
+		}
+
+		return diag.FromErr(setTypedPluginToResourceData(d, updatedPlugin, config))
+	}
+}
+
+// validateTypedPluginConfig checks the planned plugin against the schema of
+// the plugin on the Kong node, like validatePluginConfig.
+func validateTypedPluginConfig(name string, config map[string]*schema.Schema) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" && !d.HasChanges("config", "protocols", "service", "route", "consumer") {
+			return nil
+		}
+
+		raw := cty.NullVal(cty.DynamicPseudoType)
+		if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() {
+			if !rawConfig.GetAttr("config").IsWhollyKnown() {
+				return nil
+			}
+			raw = configBlock(rawConfig.GetAttr("config"))
+		}
+
+		plugin := &client.Plugin{Name: name}
+		if blocks := d.Get("config").([]interface{}); len(blocks) > 0 && blocks[0] != nil {
+			plugin.Configuration = expandPluginConfig(config, blocks[0].(map[string]interface{}), raw)
+		}
+
+		return validatePlugin(ctx, d, meta, plugin, func(err error) diag.Diagnostics {
+			return typedPluginDiagnostics("invalid "+name+" plugin", err, config)
+		})
+	}
+}
+
+func buildTypedPlugin(d *schema.ResourceData, name string, config map[string]*schema.Schema, versions attributeVersions, version client.Version) *client.Plugin {
+	plugin := buildPluginScope(d)
+	plugin.Name = name
+
+	blocks := d.Get("config").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return plugin
+	}
+
+	raw := cty.NullVal(cty.DynamicPseudoType)
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() {
+		raw = configBlock(rawConfig.GetAttr("config"))
+	}
+
+	plugin.Configuration = expandPluginConfig(config, blocks[0].(map[string]interface{}), raw)
+	for attribute := range versions {
+		if !versions.supports(version, attribute) {
+			delete(plugin.Configuration, strings.TrimPrefix(attribute, "config."))
+		}
+	}
+
+	return plugin
+}
+
+func setTypedPluginToResourceData(d *schema.ResourceData, plugin *client.Plugin, config map[string]*schema.Schema) error {
+	_ = d.Set("config", []interface{}{flattenPluginConfig(config, plugin.Configuration)})
+
+	return setPluginScopeToResourceData(d, plugin)
+}
+
+// expandPluginConfig returns the Kong configuration described by value, the
+// content of a block with the given schema, and raw, the same block as
+// written in the configuration.
+func expandPluginConfig(s map[string]*schema.Schema, value map[string]interface{}, raw cty.Value) map[string]interface{} {
+	config := map[string]interface{}{}
+
+	for name, attribute := range s {
+		rawAttribute := cty.NullVal(cty.DynamicPseudoType)
+		if !raw.IsNull() && raw.IsKnown() && raw.Type().IsObjectType() && raw.Type().HasAttribute(name) {
+			rawAttribute = raw.GetAttr(name)
+		}
+
+		if record, ok := attribute.Elem.(*schema.Resource); ok {
+			blocks, _ := value[name].([]interface{})
+			if len(blocks) == 0 || blocks[0] == nil {
+				continue
+			}
+			config[name] = expandPluginConfig(record.Schema, blocks[0].(map[string]interface{}), configBlock(rawAttribute))
+			continue
+		}
+
+		switch {
+		case !rawAttribute.IsNull() || attribute.Default != nil:
+			config[name] = value[name]
+		case attribute.Computed:
+			// Left for Kong to default.
+		case attribute.Type == schema.TypeList:
+			// Kong defaults array fields to empty arrays rather than null.
+			config[name] = []interface{}{}
+		default:
+			config[name] = nil
+		}
+	}
+
+	return config
+}
+
+// flattenPluginConfig returns the content of a block with the given schema
+// holding the Kong configuration config.
+func flattenPluginConfig(s map[string]*schema.Schema, config map[string]interface{}) map[string]interface{} {
+	value := map[string]interface{}{}
+
+	for name, attribute := range s {
+		field, ok := config[name]
+		if !ok || field == nil {
+			continue
+		}
+
+		if record, ok := attribute.Elem.(*schema.Resource); ok {
+			if nested, ok := field.(map[string]interface{}); ok {
+				value[name] = []interface{}{flattenPluginConfig(record.Schema, nested)}
+			}
+			continue
+		}
+
+		value[name] = flattenPluginConfigValue(attribute, field)
+	}
+
+	return value
+}
+
+// flattenPluginConfigValue converts the numbers decoded from JSON into the
+// integers expected by TypeInt attributes and lists.
+func flattenPluginConfigValue(attribute *schema.Schema, field interface{}) interface{} {
+	if number, ok := field.(float64); ok && attribute.Type == schema.TypeInt {
+		return int(number)
+	}
+
+	if list, ok := field.([]interface{}); ok {
+		if elem, ok := attribute.Elem.(*schema.Schema); ok && elem.Type == schema.TypeInt {
+			integers := make([]interface{}, len(list))
+			for i, item := range list {
+				integers[i] = flattenPluginConfigValue(elem, item)
+			}
+			return integers
+		}
+	}
+
+	return field
+}
+
+// typedPluginDiagnostics turns err into diagnostics like errorDiagnostics,
+// the errors of the configuration fields pointing at their attribute in the
+// config block.
+func typedPluginDiagnostics(summary string, err error, config map[string]*schema.Schema) diag.Diagnostics {
+	var kongError *client.Error
+	if !errors.As(err, &kongError) {
+		return errorDiagnostics(summary, err)
+	}
+	fields, ok := kongError.Fields["config"].(map[string]interface{})
+	if !ok {
+		return errorDiagnostics(summary, err)
+	}
+
+	others := *kongError
+	others.Fields = map[string]interface{}{}
+	for field, value := range kongError.Fields {
+		if field != "config" {
+			others.Fields[field] = value
+		}
+	}
+
+	var diags diag.Diagnostics
+	if len(others.Fields) > 0 {
+		diags = errorDiagnostics(summary, &others)
+	}
+
+	if kongError.Name != "" {
+		summary += ": " + kongError.Name
+	}
+
+	return append(diags, pluginConfigDiagnostics(summary, config, fields, "config", cty.GetAttrPath("config").IndexInt(0))...)
+}
+
+// pluginConfigDiagnostics returns one diagnostic per failing field of a
+// block with the given schema, nested records being walked into.
+func pluginConfigDiagnostics(summary string, s map[string]*schema.Schema, fields map[string]interface{}, field string, path cty.Path) diag.Diagnostics {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var diags diag.Diagnostics
+	for _, name := range names {
+		attribute, ok := s[name]
+		if !ok {
+			// Entity level checks of the record, or a field the block
+			// doesn't know.
+			for _, message := range flattenFieldErrors(joinField(field, name), fields[name]) {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       summary,
+					Detail:        message,
+					AttributePath: path,
+				})
+			}
+			continue
+		}
+
+		attributePath := path.GetAttr(name)
+		if record, ok := attribute.Elem.(*schema.Resource); ok {
+			if nested, ok := fields[name].(map[string]interface{}); ok {
+				diags = append(diags, pluginConfigDiagnostics(summary, record.Schema, nested, joinField(field, name), attributePath.IndexInt(0))...)
+				continue
+			}
+		}
+
+		for _, message := range flattenFieldErrors(joinField(field, name), fields[name]) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       summary,
+				Detail:        message,
+				AttributePath: attributePath,
+			})
+		}
+	}
+
+	return diags
+}
+
+// configBlock returns the single element of a block list as written in the
+// configuration, null when it is absent.
+func configBlock(list cty.Value) cty.Value {
+	if list.IsNull() || !list.IsKnown() || !list.CanIterateElements() || list.LengthInt() == 0 {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	return list.Index(cty.NumberIntVal(0))
+}
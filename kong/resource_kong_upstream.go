@@ -1,97 +1,37 @@
 package kong
 
 import (
-	"fmt"
-	"net/http"
+	"context"
 
 	"github.com/WeKnowSports/terraform-provider-kong/helper"
-	"github.com/dghubble/sling"
 
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-var (
-	HealthchecksTypes = []string{"http", "tcp", "https"}
-)
-
-type PassiveHealthy struct {
-	Successes    int   `json:"successes"`
-	HttpStatuses []int `json:"http_statuses,omitempty"`
-}
-
-type PassiveUnhealthy struct {
-	HttpFailures int   `json:"http_failures"`
-	HttpStatuses []int `json:"http_statuses,omitempty"`
-	TcpFailures  int   `json:"tcp_failures"`
-	Timeouts     int   `json:"timeouts"`
-}
-
-type HealthChecksPassive struct {
-	Type      string            `json:"type,omitempty"`
-	Healthy   *PassiveHealthy   `json:"healthy,omitempty"`
-	Unhealthy *PassiveUnhealthy `json:"unhealthy,omitempty"`
-}
-
-type ActiveHealthy struct {
-	Successes    int   `json:"successes"`
-	Interval     int   `json:"interval"`
-	HttpStatuses []int `json:"http_statuses,omitempty"`
-}
-
-type ActiveUnhealthy struct {
-	HttpStatuses []int `json:"http_statuses,omitempty"`
-	TcpFailures  int   `json:"tcp_failures"`
-	Timeouts     int   `json:"timeouts"`
-	HttpFailures int   `json:"http_failures"`
-	Interval     int   `json:"interval"`
-}
-
-type HealthChecksActive struct {
-	HttpsVerifyCertificate bool             `json:"https_verify_certificate"`
-	HttpPath               string           `json:"http_path,omitempty"`
-	Timeout                int              `json:"timeout,omitempty"`
-	HttpsSni               *string          `json:"https_sni,omitempty"`
-	Concurrency            int              `json:"concurrency,omitempty"`
-	Type                   string           `json:"type,omitempty"`
-	Healthy                *ActiveHealthy   `json:"healthy,omitempty"`
-	Unhealthy              *ActiveUnhealthy `json:"unhealthy,omitempty"`
-}
-
-type UpstreamHealthChecks struct {
-	Active  *HealthChecksActive  `json:"active,omitempty"`
-	Passive *HealthChecksPassive `json:"passive,omitempty"`
-}
-
-type Upstream struct {
-	ID                      string                `json:"id,omitempty"`
-	Name                    string                `json:"name,omitempty"`
-	Algorithm               string                `json:"algorithm,omitempty"`
-	HashOn                  string                `json:"hash_on"`
-	HashFallback            string                `json:"hash_fallback"`
-	HashOnHeader            string                `json:"hash_on_header,omitempty"`
-	HashFallbackHeader      string                `json:"hash_fallback_header,omitempty"`
-	HashOnCookie            string                `json:"hash_on_cookie,omitempty"`
-	HashOnCookiePath        string                `json:"hash_on_cookie_path,omitempty"`
-	HashOnQueryArg          string                `json:"hash_on_query_arg,omitempty"`
-	HashFallbackOnQueryArg  string                `json:"hash_fallback_query_arg,omitempty"`
-	HashOnUriCapture        string                `json:"hash_on_uri_capture,omitempty"`
-	HashFallbacOnUriCapture string                `json:"hash_fallback_uri_capture,omitempty"`
-	Slots                   int                   `json:"slots,omitempty"`
-	HealthChecks            *UpstreamHealthChecks `json:"healthchecks,omitempty"`
-	Tags                    []string              `json:"tags"`
-	HostHeader              string                `json:"host_header,omitempty"`
-	ClientCertificate       Certificate           `json:"-"`
-	UseSrvName              bool                  `json:"use_srv_name"`
+// upstreamAttributeVersions lists the attributes which appeared after
+// minimumKongVersion.
+var upstreamAttributeVersions = attributeVersions{
+	"use_srv_name":              {Major: 2, Minor: 5},
+	"hash_on_query_arg":         {Major: 3},
+	"hash_fallback_query_arg":   {Major: 3},
+	"hash_on_uri_capture":       {Major: 3},
+	"hash_fallback_uri_capture": {Major: 3},
 }
 
 func resourceKongUpstream() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceKongUpstreamCreate,
-		Read:   resourceKongUpstreamRead,
-		Update: resourceKongUpstreamUpdate,
-		Delete: resourceKongUpstreamDelete,
+		CreateContext: resourceKongUpstreamCreate,
+		ReadContext:   resourceKongUpstreamRead,
+		UpdateContext: resourceKongUpstreamUpdate,
+		DeleteContext: resourceKongUpstreamDelete,
+
+		CustomizeDiff: checkAttributeVersions(upstreamAttributeVersions),
 
 		Schema: map[string]*schema.Schema{
+			"workspace": workspaceSchema(),
+
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -371,20 +311,19 @@ func resourceKongUpstream() *schema.Resource {
 	}
 }
 
-func resourceKongUpstreamCreate(d *schema.ResourceData, meta interface{}) error {
-	Sling := meta.(*sling.Sling)
-
-	upstream := getUpstreamFromResourceData(d)
-
-	createdUpstream := getUpstreamFromResourceData(d)
+func resourceKongUpstreamCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	response, Error := Sling.New().BodyJSON(upstream).Post("upstreams/").ReceiveSuccess(createdUpstream)
-	if Error != nil {
-		return fmt.Errorf("error while creating upstream")
+	version, err := kongVersion(ctx, meta)
+	if err != nil {
+		return errorDiagnostics("error while reading Kong node information", err)
 	}
 
-	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf(response.Status)
+	upstream := getUpstreamFromResourceData(d, version)
+
+	createdUpstream, err := c.Upstreams.Create(ctx, upstream)
+	if err != nil {
+		return errorDiagnostics("error while creating upstream", err)
 	}
 
 	setUpstreamToResourceData(d, createdUpstream)
@@ -392,21 +331,15 @@ func resourceKongUpstreamCreate(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceKongUpstreamRead(d *schema.ResourceData, meta interface{}) error {
-	Sling := meta.(*sling.Sling)
-
-	upstream := getUpstreamFromResourceData(d)
-
-	response, Error := Sling.New().Path("upstreams/").Get(upstream.ID).ReceiveSuccess(upstream)
-	if Error != nil {
-		return fmt.Errorf(Error.Error()) //fmt.Errorf("Error while updating upstream")
-	}
+func resourceKongUpstreamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	if response.StatusCode == http.StatusNotFound {
+	upstream, err := c.Upstreams.Get(ctx, d.Id())
+	if client.IsNotFound(err) {
 		d.SetId("")
 		return nil
-	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	} else if err != nil {
+		return errorDiagnostics("error while reading upstream", err)
 	}
 
 	setUpstreamToResourceData(d, upstream)
@@ -414,19 +347,19 @@ func resourceKongUpstreamRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceKongUpstreamUpdate(d *schema.ResourceData, meta interface{}) error {
-	Sling := meta.(*sling.Sling)
+func resourceKongUpstreamUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	upstream := getUpstreamFromResourceData(d)
-	updatedUpstream := getUpstreamFromResourceData(d)
-
-	response, Error := Sling.New().BodyJSON(upstream).Path("upstreams/").Patch(upstream.ID).ReceiveSuccess(updatedUpstream)
-	if Error != nil {
-		return fmt.Errorf(Error.Error()) //fmt.Errorf("Error while updating upstream")
+	version, err := kongVersion(ctx, meta)
+	if err != nil {
+		return errorDiagnostics("error while reading Kong node information", err)
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(response.Status)
+	upstream := getUpstreamFromResourceData(d, version)
+
+	updatedUpstream, err := c.Upstreams.Update(ctx, upstream)
+	if err != nil {
+		return errorDiagnostics("error while updating upstream", err)
 	}
 
 	setUpstreamToResourceData(d, updatedUpstream)
@@ -434,27 +367,21 @@ func resourceKongUpstreamUpdate(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceKongUpstreamDelete(d *schema.ResourceData, meta interface{}) error {
-	Sling := meta.(*sling.Sling)
-
-	upstream := getUpstreamFromResourceData(d)
-
-	response, Error := Sling.New().Path("upstreams/").Delete(upstream.ID).ReceiveSuccess(nil)
-	if Error != nil {
-		return fmt.Errorf("error while deleting upstream")
-	}
+func resourceKongUpstreamDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := clientFor(d, meta)
 
-	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf(response.Status)
+	err := c.Upstreams.Delete(ctx, d.Id())
+	if err != nil {
+		return errorDiagnostics("error while deleting upstream", err)
 	}
 
 	return nil
 }
 
-func getActiveHealthyFromMap(d *map[string]interface{}) *ActiveHealthy {
+func getActiveHealthyFromMap(d *map[string]interface{}) *client.ActiveHealthy {
 	if d != nil {
 		m := *d
-		healthy := &ActiveHealthy{}
+		healthy := &client.ActiveHealthy{}
 
 		if m["interval"] != nil {
 			healthy.Interval = m["interval"].(int)
@@ -471,10 +398,10 @@ func getActiveHealthyFromMap(d *map[string]interface{}) *ActiveHealthy {
 	return nil
 }
 
-func getActiveUnhealthyFromMap(d *map[string]interface{}) *ActiveUnhealthy {
+func getActiveUnhealthyFromMap(d *map[string]interface{}) *client.ActiveUnhealthy {
 	if d != nil {
 		m := *d
-		unhealthy := &ActiveUnhealthy{}
+		unhealthy := &client.ActiveUnhealthy{}
 
 		if m["interval"] != nil {
 			unhealthy.Interval = m["interval"].(int)
@@ -497,10 +424,10 @@ func getActiveUnhealthyFromMap(d *map[string]interface{}) *ActiveUnhealthy {
 	return nil
 }
 
-func getActiveHealthChecksFromMap(d *map[string]interface{}) *HealthChecksActive {
+func getActiveHealthChecksFromMap(d *map[string]interface{}) *client.HealthChecksActive {
 	if d != nil {
 		m := *d
-		active := &HealthChecksActive{}
+		active := &client.HealthChecksActive{}
 
 		if m["type"] != nil {
 			active.Type = m["type"].(string)
@@ -544,10 +471,10 @@ func getActiveHealthChecksFromMap(d *map[string]interface{}) *HealthChecksActive
 	return nil
 }
 
-func getPassiveHealthyFromMap(d *map[string]interface{}) *PassiveHealthy {
+func getPassiveHealthyFromMap(d *map[string]interface{}) *client.PassiveHealthy {
 	if d != nil {
 		m := *d
-		healthy := &PassiveHealthy{}
+		healthy := &client.PassiveHealthy{}
 
 		if m["http_statuses"] != nil {
 			healthy.HttpStatuses = readIntArrayFromInterface(m["http_statuses"])
@@ -561,10 +488,10 @@ func getPassiveHealthyFromMap(d *map[string]interface{}) *PassiveHealthy {
 	return nil
 }
 
-func getPassiveUnhealthyFromMap(d *map[string]interface{}) *PassiveUnhealthy {
+func getPassiveUnhealthyFromMap(d *map[string]interface{}) *client.PassiveUnhealthy {
 	if d != nil {
 		m := *d
-		unhealthy := &PassiveUnhealthy{}
+		unhealthy := &client.PassiveUnhealthy{}
 
 		if m["http_statuses"] != nil {
 			unhealthy.HttpStatuses = readIntArrayFromInterface(m["http_statuses"])
@@ -584,10 +511,10 @@ func getPassiveUnhealthyFromMap(d *map[string]interface{}) *PassiveUnhealthy {
 	return nil
 }
 
-func getPassiveHealthsCheckFromMap(d *map[string]interface{}) *HealthChecksPassive {
+func getPassiveHealthsCheckFromMap(d *map[string]interface{}) *client.HealthChecksPassive {
 	if d != nil {
 		m := *d
-		passive := &HealthChecksPassive{}
+		passive := &client.HealthChecksPassive{}
 
 		if m["type"] != nil {
 			passive.Type = m["type"].(string)
@@ -613,10 +540,10 @@ func getPassiveHealthsCheckFromMap(d *map[string]interface{}) *HealthChecksPassi
 	return nil
 }
 
-func getHealthChecksFromMap(d *map[string]interface{}) *UpstreamHealthChecks {
+func getHealthChecksFromMap(d *map[string]interface{}) *client.UpstreamHealthChecks {
 	if d != nil {
 		m := *d
-		healthChecks := &UpstreamHealthChecks{}
+		healthChecks := &client.UpstreamHealthChecks{}
 
 		if m["active"] != nil {
 			if activeArray := m["active"].([]interface{}); len(activeArray) > 0 {
@@ -638,8 +565,10 @@ func getHealthChecksFromMap(d *map[string]interface{}) *UpstreamHealthChecks {
 	return nil
 }
 
-func getUpstreamFromResourceData(d *schema.ResourceData) *Upstream {
-	upstream := &Upstream{
+// getUpstreamFromResourceData builds the Upstream payload, leaving out the
+// attributes the Kong release doesn't support.
+func getUpstreamFromResourceData(d *schema.ResourceData, version client.Version) *client.Upstream {
+	upstream := &client.Upstream{
 		ID:                      d.Id(),
 		Name:                    d.Get("name").(string),
 		Algorithm:               d.Get("algorithm").(string),
@@ -656,10 +585,14 @@ func getUpstreamFromResourceData(d *schema.ResourceData) *Upstream {
 		Slots:                   d.Get("slots").(int),
 		Tags:                    helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
 		HostHeader:              d.Get("host_header").(string),
-		ClientCertificate: Certificate{
+		ClientCertificate: client.Certificate{
 			ID: d.Get("client_certificate").(string),
 		},
-		UseSrvName: d.Get("use_srv_name").(bool),
+	}
+
+	if upstreamAttributeVersions.supports(version, "use_srv_name") {
+		useSrvName := d.Get("use_srv_name").(bool)
+		upstream.UseSrvName = &useSrvName
 	}
 
 	hcArr := d.Get("healthchecks").([]interface{})
@@ -672,7 +605,7 @@ func getUpstreamFromResourceData(d *schema.ResourceData) *Upstream {
 	return upstream
 }
 
-func convertActiveHealthyToResourceData(ah *ActiveHealthy) []map[string]interface{} {
+func convertActiveHealthyToResourceData(ah *client.ActiveHealthy) []map[string]interface{} {
 	if ah == nil {
 		return []map[string]interface{}{}
 	}
@@ -685,7 +618,7 @@ func convertActiveHealthyToResourceData(ah *ActiveHealthy) []map[string]interfac
 	return []map[string]interface{}{m}
 }
 
-func convertActiveUnhealthyToResource(au *ActiveUnhealthy) []map[string]interface{} {
+func convertActiveUnhealthyToResource(au *client.ActiveUnhealthy) []map[string]interface{} {
 	if au == nil {
 		return []map[string]interface{}{}
 	}
@@ -700,7 +633,7 @@ func convertActiveUnhealthyToResource(au *ActiveUnhealthy) []map[string]interfac
 	return []map[string]interface{}{m}
 }
 
-func convertHealthCheckActiveToResourceData(hca *HealthChecksActive) []interface{} {
+func convertHealthCheckActiveToResourceData(hca *client.HealthChecksActive) []interface{} {
 	if hca == nil {
 		return []interface{}{}
 	}
@@ -725,7 +658,7 @@ func convertHealthCheckActiveToResourceData(hca *HealthChecksActive) []interface
 	return []interface{}{m}
 }
 
-func convertPassiveHealthyToResourceData(ph *PassiveHealthy) []map[string]interface{} {
+func convertPassiveHealthyToResourceData(ph *client.PassiveHealthy) []map[string]interface{} {
 	if ph == nil {
 		return []map[string]interface{}{}
 	}
@@ -737,7 +670,7 @@ func convertPassiveHealthyToResourceData(ph *PassiveHealthy) []map[string]interf
 	return []map[string]interface{}{m}
 }
 
-func convertPassiveUnhealthyToResourceData(pu *PassiveUnhealthy) []map[string]interface{} {
+func convertPassiveUnhealthyToResourceData(pu *client.PassiveUnhealthy) []map[string]interface{} {
 	if pu == nil {
 		return []map[string]interface{}{}
 	}
@@ -751,7 +684,7 @@ func convertPassiveUnhealthyToResourceData(pu *PassiveUnhealthy) []map[string]in
 	return []map[string]interface{}{m}
 }
 
-func convertHealthCheckPassiveToResourceData(in *HealthChecksPassive) []interface{} {
+func convertHealthCheckPassiveToResourceData(in *client.HealthChecksPassive) []interface{} {
 	if in == nil {
 		return []interface{}{}
 	}
@@ -770,7 +703,7 @@ func convertHealthCheckPassiveToResourceData(in *HealthChecksPassive) []interfac
 	return []interface{}{m}
 }
 
-func convertHealthCheckResourceData(uhc *UpstreamHealthChecks) []interface{} {
+func convertHealthCheckResourceData(uhc *client.UpstreamHealthChecks) []interface{} {
 	if uhc == nil {
 		return []interface{}{}
 	}
@@ -787,7 +720,7 @@ func convertHealthCheckResourceData(uhc *UpstreamHealthChecks) []interface{} {
 	return []interface{}{m}
 }
 
-func setUpstreamToResourceData(d *schema.ResourceData, upstream *Upstream) {
+func setUpstreamToResourceData(d *schema.ResourceData, upstream *client.Upstream) {
 	d.SetId(upstream.ID)
 	d.Set("name", upstream.Name)
 	d.Set("algorithm", upstream.Algorithm)
@@ -805,7 +738,9 @@ func setUpstreamToResourceData(d *schema.ResourceData, upstream *Upstream) {
 	d.Set("tags", upstream.Tags)
 	d.Set("host_header", upstream.HostHeader)
 	d.Set("client_certificate", upstream.ClientCertificate)
-	d.Set("use_srv_name", upstream.UseSrvName)
+	if upstream.UseSrvName != nil {
+		d.Set("use_srv_name", *upstream.UseSrvName)
+	}
 }
 
 func readIntArrayFromInterface(in interface{}) []int {
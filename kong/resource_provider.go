@@ -1,7 +1,16 @@
 package kong
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns a terraform.ResourceProvider.
@@ -9,18 +18,121 @@ func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"address": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_ADMIN_ADDR", nil),
+				Description: "The address of the Kong Admin API. Can also be set with KONG_ADMIN_ADDR.",
 			},
 			"username": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_ADMIN_USERNAME", ""),
+				Description: "Username for basic authentication on the Admin API. Can also be set with KONG_ADMIN_USERNAME.",
 			},
 			"password": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_ADMIN_PASSWORD", ""),
+				Sensitive:   true,
+				Description: "Password for basic authentication on the Admin API. Can also be set with KONG_ADMIN_PASSWORD.",
+			},
+			"workspace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_WORKSPACE", ""),
+				Description: "The Kong Enterprise workspace managed by default. Resources can override it with their own workspace attribute. Empty targets the Admin API root, i.e. the default workspace. Can also be set with KONG_WORKSPACE.",
+			},
+			"admin_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_ADMIN_TOKEN", ""),
+				Sensitive:   true,
+				Description: "RBAC token sent in the Kong-Admin-Token header (Kong Enterprise). Can also be set with KONG_ADMIN_TOKEN.",
+			},
+			"api_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_ADMIN_API_KEY", ""),
+				Sensitive:   true,
+				Description: "Key sent in the api_key_header header, for Admin APIs exposed through a gateway protected by key-auth. Can also be set with KONG_ADMIN_API_KEY.",
+			},
+			"api_key_header": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_ADMIN_API_KEY_HEADER", "apikey"),
+				Description: "Name of the header carrying api_key. Defaults to \"apikey\", the key-auth plugin default. Can also be set with KONG_ADMIN_API_KEY_HEADER.",
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Static headers added to every request sent to the Admin API. When unset, read from KONG_ADMIN_HEADERS as comma separated name=value pairs.",
+			},
+			"tls_ca_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_ADMIN_TLS_CA_CERT", ""),
+				Description: "PEM-encoded CA bundle used to verify the Admin API certificate instead of the system roots. Can also be set with KONG_ADMIN_TLS_CA_CERT.",
+			},
+			"tls_client_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_ADMIN_TLS_CLIENT_CERT", ""),
+				Description: "PEM-encoded client certificate presented to Admin APIs requiring mutual TLS. Can also be set with KONG_ADMIN_TLS_CLIENT_CERT.",
+			},
+			"tls_client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_ADMIN_TLS_CLIENT_KEY", ""),
+				Sensitive:   true,
+				Description: "PEM-encoded private key of tls_client_cert. Can also be set with KONG_ADMIN_TLS_CLIENT_KEY.",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_ADMIN_TLS_SERVER_NAME", ""),
+				Description: "Server name used for SNI and to verify the Admin API certificate, when it differs from the address host. Can also be set with KONG_ADMIN_TLS_SERVER_NAME.",
+			},
+			"tls_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_ADMIN_TLS_SKIP_VERIFY", false),
+				Description: "Skip verification of the Admin API certificate. Only meant for lab clusters. Can also be set with KONG_ADMIN_TLS_SKIP_VERIFY.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KONG_ADMIN_MAX_RETRIES", 3),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "How many times idempotent requests failing on the network or with a 429, 500, 502, 503 or 504 status are retried. Creations and updates are only retried when the connection is refused or Kong answers 429 or 503. Defaults to 3. Can also be set with KONG_ADMIN_MAX_RETRIES.",
+			},
+			"retry_backoff_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KONG_ADMIN_RETRY_BACKOFF_MIN", 500),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The minimum wait in milliseconds before retrying a request. Doubles on every attempt. Defaults to 500. Can also be set with KONG_ADMIN_RETRY_BACKOFF_MIN.",
+			},
+			"retry_backoff_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KONG_ADMIN_RETRY_BACKOFF_MAX", 30000),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum wait in milliseconds before retrying a request, including the waits asked by Kong with Retry-After. Defaults to 30000. Can also be set with KONG_ADMIN_RETRY_BACKOFF_MAX.",
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KONG_ADMIN_REQUEST_TIMEOUT", 60000),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The timeout in milliseconds of a single Admin API request attempt. 0 disables it. Defaults to 60000. Can also be set with KONG_ADMIN_REQUEST_TIMEOUT.",
+			},
+			"strict_routing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KONG_STRICT_ROUTING", false),
+				Description: "Fail the plan when a kong_route is identical to, shadows or is shadowed by another route, in Kong or in the same plan. Otherwise the conflict is only logged during the plan, Terraform not letting providers warn from a plan, and reported as a warning when the route is applied or read. Can also be set with KONG_STRICT_ROUTING.",
 			},
 		},
 
@@ -38,18 +150,110 @@ func Provider() *schema.Provider {
 			"kong_sni":                            resourceKongSNI(),
 			"kong_upstream":                       resourceKongUpstream(),
 			"kong_target":                         resourceKongTarget(),
+			"kong_plugin_rate_limiting":           resourceKongPluginRateLimiting(),
+			"kong_plugin_cors":                    resourceKongPluginCORS(),
+			"kong_plugin_key_auth":                resourceKongPluginKeyAuth(),
+			"kong_plugin_jwt":                     resourceKongPluginJWT(),
+			"kong_plugin_acl":                     resourceKongPluginACL(),
+			"kong_plugin_request_transformer":     resourceKongPluginRequestTransformer(),
+			"kong_plugin_prometheus":              resourceKongPluginPrometheus(),
+			"kong_plugin_ip_restriction":          resourceKongPluginIPRestriction(),
 		},
 
-		ConfigureFunc: providerConfigure,
+		DataSourcesMap: map[string]*schema.Resource{
+			"kong_service":         dataSourceKongService(),
+			"kong_services":        dataSourceKongServices(),
+			"kong_routes":          dataSourceKongRoutes(),
+			"kong_route_match":     dataSourceKongRouteMatch(),
+			"kong_consumer":        dataSourceKongConsumer(),
+			"kong_consumers":       dataSourceKongConsumers(),
+			"kong_upstream_health": dataSourceKongUpstreamHealth(),
+			"kong_node":            dataSourceKongNode(),
+		},
+
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	config := Config{
 		Address:  d.Get("address").(string),
 		Username: d.Get("username").(string),
 		Password: d.Get("password").(string),
+
+		Workspace: d.Get("workspace").(string),
+
+		AdminToken:   d.Get("admin_token").(string),
+		APIKey:       d.Get("api_key").(string),
+		APIKeyHeader: d.Get("api_key_header").(string),
+		Headers:      map[string]string{},
+
+		TLSCACert:     d.Get("tls_ca_cert").(string),
+		TLSClientCert: d.Get("tls_client_cert").(string),
+		TLSClientKey:  d.Get("tls_client_key").(string),
+		TLSServerName: d.Get("tls_server_name").(string),
+		TLSSkipVerify: d.Get("tls_skip_verify").(bool),
+
+		MaxRetries:      d.Get("max_retries").(int),
+		RetryBackoffMin: time.Duration(d.Get("retry_backoff_min").(int)) * time.Millisecond,
+		RetryBackoffMax: time.Duration(d.Get("retry_backoff_max").(int)) * time.Millisecond,
+		RequestTimeout:  time.Duration(d.Get("request_timeout").(int)) * time.Millisecond,
+	}
+
+	for name, value := range d.Get("headers").(map[string]interface{}) {
+		config.Headers[name] = value.(string)
+	}
+
+	if len(config.Headers) == 0 {
+		headers, err := parseHeadersEnv(os.Getenv("KONG_ADMIN_HEADERS"))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		config.Headers = headers
+	}
+
+	c, err := config.Client()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	if diags := checkKongNode(ctx, c, config.Address); diags.HasError() {
+		return nil, diags
+	}
+
+	return &providerMeta{
+		client:        c,
+		strictRouting: d.Get("strict_routing").(bool),
+		routes:        newRouteIndex(),
+	}, nil
+}
+
+// providerMeta is shared by the resources and data sources of the provider.
+type providerMeta struct {
+	client *client.Client
+
+	// strictRouting turns the route conflicts found at plan time into errors.
+	strictRouting bool
+
+	routes *routeIndex
+}
+
+// parseHeadersEnv reads headers given as comma separated name=value pairs.
+func parseHeadersEnv(value string) (map[string]string, error) {
+	headers := map[string]string{}
+
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("KONG_ADMIN_HEADERS must contain comma separated name=value pairs")
+		}
+
+		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
 	}
 
-	return config.Client()
+	return headers, nil
 }
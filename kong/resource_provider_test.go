@@ -0,0 +1,62 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testProviderMeta configures the provider against a Kong Admin API served by
+// handler, with the given provider settings on top of its address.
+func testProviderMeta(t *testing.T, handler http.HandlerFunc, settings map[string]interface{}) interface{} {
+	t.Helper()
+
+	kong := httptest.NewServer(handler)
+	t.Cleanup(kong.Close)
+
+	raw := map[string]interface{}{"address": kong.URL}
+	for name, value := range settings {
+		raw[name] = value
+	}
+
+	meta, diags := providerConfigure(context.Background(), schema.TestResourceDataRaw(t, Provider().Schema, raw))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	return meta
+}
+
+// testPlan plans the resource from the given prior state, as Terraform core
+// does: id is empty for a creation and config holds the attributes set in
+// the configuration, the others being null.
+func testPlan(r *schema.Resource, meta interface{}, id string, state map[string]string, config map[string]cty.Value) (*terraform.InstanceDiff, error) {
+	block := r.CoreConfigSchema()
+	raw := testObject(block.ImpliedType(), config)
+
+	attributes := map[string]string{"id": id}
+	for name, value := range state {
+		attributes[name] = value
+	}
+
+	return r.SimpleDiff(context.Background(), &terraform.InstanceState{ID: id, Attributes: attributes, RawConfig: raw}, terraform.NewResourceConfigShimmed(raw, block), meta)
+}
+
+// testObject returns the object of the given type holding values, its other
+// attributes being null.
+func testObject(ty cty.Type, values map[string]cty.Value) cty.Value {
+	attributes := map[string]cty.Value{}
+	for name, attribute := range ty.AttributeTypes() {
+		attributes[name] = cty.NullVal(attribute)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+
+	return cty.ObjectVal(attributes)
+}
@@ -0,0 +1,449 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/WeKnowSports/terraform-provider-kong/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// routeIndex holds, by workspace, the routes found in Kong and the routes
+// planned so far, so that a planned route can be compared with both. Kong is
+// listed once per workspace for the lifetime of the provider.
+type routeIndex struct {
+	mu      sync.Mutex
+	kong    map[string][]*client.Route
+	planned map[string]map[string]*client.Route
+	created int
+}
+
+func newRouteIndex() *routeIndex {
+	return &routeIndex{
+		kong:    map[string][]*client.Route{},
+		planned: map[string]map[string]*client.Route{},
+	}
+}
+
+// existing returns the routes found in Kong in the workspace of c.
+func (i *routeIndex) existing(ctx context.Context, c *client.Client) ([]*client.Route, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.list(ctx, c)
+}
+
+// list is existing for the callers holding i.mu.
+func (i *routeIndex) list(ctx context.Context, c *client.Client) ([]*client.Route, error) {
+	workspace := c.WorkspaceName()
+
+	if existing, ok := i.kong[workspace]; ok {
+		return existing, nil
+	}
+
+	existing, err := c.Routes.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	i.kong[workspace] = existing
+
+	return existing, nil
+}
+
+// plan records the planned route and returns the other routes of its
+// workspace, as merged by mergePlannedRoutes. Routes to be created are
+// recorded under a key of their own, since nothing else tells them apart.
+func (i *routeIndex) plan(ctx context.Context, c *client.Client, route *client.Route) ([]*client.Route, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	existing, err := i.list(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace := c.WorkspaceName()
+	if i.planned[workspace] == nil {
+		i.planned[workspace] = map[string]*client.Route{}
+	}
+	planned := i.planned[workspace]
+
+	key := route.ID
+	if key == "" {
+		i.created++
+		key = "created:" + strconv.Itoa(i.created)
+	}
+
+	for _, other := range existing {
+		if route.ID != "" && other.ID == route.ID {
+			route.CreatedAt = other.CreatedAt
+		}
+	}
+	planned[key] = route
+
+	others := mergePlannedRoutes(existing, planned, key)
+	sortRoutes(others)
+
+	return others, nil
+}
+
+// withPlanned returns the routes listed from Kong in the workspace of c with
+// the routes planned so far, as merged by mergePlannedRoutes. A route planned
+// for creation which Kong already holds, with the same name and matchers, has
+// been created since and is left out.
+func (i *routeIndex) withPlanned(c *client.Client, routes []*client.Route) []*client.Route {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	planned := map[string]*client.Route{}
+	for key, route := range i.planned[c.WorkspaceName()] {
+		if route.ID == "" && anyRoute(routes, func(other *client.Route) bool {
+			return other.Name == route.Name && routesIdentical(other, route)
+		}) {
+			continue
+		}
+		planned[key] = route
+	}
+
+	return mergePlannedRoutes(routes, planned, "")
+}
+
+// mergePlannedRoutes returns the routes found in Kong with the planned ones,
+// but the one recorded under the key except. The planned versions replace
+// the routes found in Kong and, route names being unique in Kong, a route
+// planned for creation replaces the route of the same name: its resource is
+// being replaced or moved to another address, the other route being
+// destroyed.
+func mergePlannedRoutes(existing []*client.Route, planned map[string]*client.Route, except string) []*client.Route {
+	keys := make([]string, 0, len(planned))
+	created := map[string]string{}
+	for key, route := range planned {
+		keys = append(keys, key)
+		if route.ID == "" && route.Name != "" {
+			created[route.Name] = key
+		}
+	}
+	sort.Strings(keys)
+
+	replaced := func(route *client.Route, key string) bool {
+		creation, ok := created[route.Name]
+		return route.Name != "" && ok && creation != key
+	}
+
+	var merged []*client.Route
+	plannedIDs := map[string]bool{}
+	for _, key := range keys {
+		route := planned[key]
+		if route.ID != "" {
+			plannedIDs[route.ID] = true
+		}
+		if key != except && !replaced(route, key) {
+			merged = append(merged, route)
+		}
+	}
+	for _, route := range existing {
+		if !plannedIDs[route.ID] && !replaced(route, "") {
+			merged = append(merged, route)
+		}
+	}
+
+	return merged
+}
+
+// anyRoute reports whether f holds for any of the routes.
+func anyRoute(routes []*client.Route, f func(*client.Route) bool) bool {
+	for _, route := range routes {
+		if f(route) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortRoutes sorts routes by creation time, for conflicts to be reported in
+// a stable order.
+func sortRoutes(routes []*client.Route) {
+	sort.SliceStable(routes, func(a, b int) bool {
+		if routes[a].CreatedAt != routes[b].CreatedAt {
+			return routes[a].CreatedAt < routes[b].CreatedAt
+		}
+		return routes[a].ID < routes[b].ID
+	})
+}
+
+// checkRouteConflicts reports the routes, in Kong or in the same plan, the
+// planned route is identical to, shadows or is shadowed by. They fail the
+// plan when strict_routing is set. Otherwise they are only logged, the SDK
+// having no way to return warnings from a plan, and routeConflictWarnings
+// reports them when the route is applied or read.
+func checkRouteConflicts(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// A route moving to another workspace is planned again as a new route.
+	if d.Id() != "" && d.HasChange("workspace") {
+		return nil
+	}
+
+	route, ok := plannedRoute(d)
+	if !ok || !isTraditionalHTTPRoute(route) {
+		return nil
+	}
+
+	c := clientForDiff(d, meta)
+
+	version, err := kongVersion(ctx, meta)
+	if err != nil {
+		return err
+	}
+	v3 := version.AtLeast(client.Version{Major: 3})
+
+	others, err := meta.(*providerMeta).routes.plan(ctx, c, route)
+	if err != nil {
+		return fmt.Errorf("error while listing Routes: %w", err)
+	}
+
+	conflicts := routeConflicts(route, others, v3)
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	if meta.(*providerMeta).strictRouting {
+		return fmt.Errorf("%s", strings.Join(conflicts, "; "))
+	}
+
+	for _, conflict := range conflicts {
+		log.Printf("[WARN] kong_route: %s", conflict)
+	}
+
+	return nil
+}
+
+// routeConflictWarnings warns about the routes in Kong the route is identical
+// to, shadows or is shadowed by. A route of the same name is the one it
+// replaced, route names being unique in Kong.
+func routeConflictWarnings(ctx context.Context, c *client.Client, meta interface{}, version client.Version, route *client.Route) diag.Diagnostics {
+	if !isTraditionalHTTPRoute(route) {
+		return nil
+	}
+
+	existing, err := meta.(*providerMeta).routes.existing(ctx, c)
+	if err != nil {
+		return errorDiagnostics("error while listing Routes", err)
+	}
+
+	var others []*client.Route
+	for _, other := range existing {
+		if other.ID != route.ID && (route.Name == "" || other.Name != route.Name) {
+			others = append(others, other)
+		}
+	}
+	sortRoutes(others)
+
+	var diags diag.Diagnostics
+	for _, conflict := range routeConflicts(route, others, version.AtLeast(client.Version{Major: 3})) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "conflicting route",
+			Detail:   conflict,
+		})
+	}
+
+	return diags
+}
+
+// isTraditionalHTTPRoute reports whether the route matches HTTP requests with
+// traditional matchers, the only routes compared for conflicts.
+func isTraditionalHTTPRoute(route *client.Route) bool {
+	return route.Expression == "" && !anyString(route.Protocols, func(protocol string) bool { return streamProtocols[protocol] })
+}
+
+// routeConflicts describes how route conflicts with others.
+func routeConflicts(route *client.Route, others []*client.Route, v3 bool) []string {
+	var conflicts []string
+	for _, other := range others {
+		if conflict := routeConflict(route, other, v3); conflict != "" {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	return conflicts
+}
+
+// plannedRoute returns the HTTP matchers of the route planned in d, false
+// when they aren't all known yet.
+func plannedRoute(d *schema.ResourceDiff) (*client.Route, bool) {
+	for _, attribute := range []string{"name", "protocols", "methods", "hosts", "paths", "header", "snis", "regex_priority", "expression", "tags"} {
+		if !d.NewValueKnown(attribute) {
+			return nil, false
+		}
+	}
+
+	route := &client.Route{
+		ID:            d.Id(),
+		Name:          d.Get("name").(string),
+		Protocols:     helper.ConvertInterfaceArrToStrings(d.Get("protocols").([]interface{})),
+		Methods:       helper.ConvertInterfaceArrToStrings(d.Get("methods").([]interface{})),
+		Hosts:         helper.ConvertInterfaceArrToStrings(d.Get("hosts").([]interface{})),
+		Paths:         helper.ConvertInterfaceArrToStrings(d.Get("paths").([]interface{})),
+		Headers:       plannedRouteHeaders(d),
+		RegexPriority: d.Get("regex_priority").(int),
+		SNIs:          helper.ConvertInterfaceArrToStrings(d.Get("snis").([]interface{})),
+		Expression:    d.Get("expression").(string),
+		Tags:          helper.ConvertInterfaceArrToStrings(d.Get("tags").([]interface{})),
+		Service: client.Reference{
+			ID: d.Get("service").(string),
+		},
+		// New routes are created after every route found in Kong.
+		CreatedAt: int(time.Now().Unix()),
+	}
+
+	return route, true
+}
+
+// routeConflict describes how route conflicts with other, empty when they
+// don't.
+func routeConflict(route, other *client.Route, v3 bool) string {
+	// Stream and expression routes are left out.
+	for _, r := range []*client.Route{route, other} {
+		if r.Expression != "" || len(r.Sources) > 0 || len(r.Destinations) > 0 {
+			return ""
+		}
+	}
+
+	name := routeDisplayName(route)
+	otherName := routeDisplayName(other)
+
+	if routesIdentical(route, other) {
+		return fmt.Sprintf("route %s matches the same requests as route %s, only one of them can ever be selected", name, otherName)
+	}
+
+	m, o := newRouteMatcher(route, v3), newRouteMatcher(other, v3)
+
+	if first, why := compareRouteMatchers(o, m); first && routeCovers(o, m) {
+		return fmt.Sprintf("route %s is shadowed by route %s, which matches every request it matches and is evaluated first as %s", name, otherName, why)
+	}
+
+	if first, why := compareRouteMatchers(m, o); first && routeCovers(m, o) {
+		return fmt.Sprintf("route %s shadows route %s, matching every request it matches and being evaluated first as %s", name, otherName, why)
+	}
+
+	return ""
+}
+
+func routeDisplayName(route *client.Route) string {
+	if route.Name != "" {
+		return route.Name
+	}
+	if route.ID != "" {
+		return route.ID
+	}
+	return "(unnamed)"
+}
+
+// routesIdentical reports whether both routes set the same matchers.
+func routesIdentical(a, b *client.Route) bool {
+	if !sameStrings(a.Protocols, b.Protocols) || !sameStrings(a.Methods, b.Methods) || !sameStrings(a.Hosts, b.Hosts) ||
+		!sameStrings(a.Paths, b.Paths) || !sameStrings(a.SNIs, b.SNIs) || len(a.Headers) != len(b.Headers) {
+		return false
+	}
+
+	for name, values := range a.Headers {
+		if !sameStrings(values, b.Headers[name]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// routeCovers reports whether every request matched by b is also matched by
+// a. Regexes are only compared as strings, so the result errs on the side of
+// not covering.
+func routeCovers(a, b *routeMatcher) bool {
+	ra, rb := a.route, b.route
+
+	if !subsetOf(rb.Protocols, ra.Protocols, strings.EqualFold) {
+		return false
+	}
+
+	if len(ra.Methods) > 0 && (len(rb.Methods) == 0 || !subsetOf(rb.Methods, ra.Methods, strings.EqualFold)) {
+		return false
+	}
+
+	if len(ra.Hosts) > 0 && (len(rb.Hosts) == 0 || !subsetOf(rb.Hosts, ra.Hosts, hostCovers)) {
+		return false
+	}
+
+	if len(ra.SNIs) > 0 && (len(rb.SNIs) == 0 || !subsetOf(rb.SNIs, ra.SNIs, strings.EqualFold)) {
+		return false
+	}
+
+	for name, values := range ra.Headers {
+		bValues, ok := rb.Headers[name]
+		if !ok {
+			for n, v := range rb.Headers {
+				if strings.EqualFold(n, name) {
+					bValues, ok = v, true
+				}
+			}
+		}
+		if !ok || !subsetOf(bValues, values, func(b, a string) bool {
+			return b == a || !strings.HasPrefix(a, "~*") && !strings.HasPrefix(b, "~*") && strings.EqualFold(a, b)
+		}) {
+			return false
+		}
+	}
+
+	if len(ra.Paths) > 0 {
+		if len(rb.Paths) == 0 {
+			return false
+		}
+		return subsetOf(rb.Paths, ra.Paths, func(pb, pa string) bool {
+			if a.isRegexPath(pa) || b.isRegexPath(pb) {
+				return pa == pb
+			}
+			return strings.HasPrefix(pb, pa)
+		})
+	}
+
+	return true
+}
+
+// hostCovers reports whether the host pattern a matches every host b does.
+func hostCovers(b, a string) bool {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+
+	switch {
+	case a == b:
+		return true
+	case !strings.Contains(b, "*"):
+		return matchRouteHost(a, b)
+	case strings.HasPrefix(a, "*") && strings.HasPrefix(b, "*"):
+		return strings.HasSuffix(b[1:], a[1:])
+	case strings.HasSuffix(a, "*") && strings.HasSuffix(b, "*"):
+		return strings.HasPrefix(b[:len(b)-1], a[:len(a)-1])
+	}
+
+	return false
+}
+
+// subsetOf reports whether every value of b is covered by a value of a.
+func subsetOf(b, a []string, covers func(b, a string) bool) bool {
+	for _, vb := range b {
+		if !anyString(a, func(va string) bool { return covers(vb, va) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameStrings reports whether a and b hold the same values, in any order.
+func sameStrings(a, b []string) bool {
+	return subsetOf(a, b, func(x, y string) bool { return x == y }) && subsetOf(b, a, func(x, y string) bool { return x == y })
+}
@@ -0,0 +1,222 @@
+package kong
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestRouteConflict(t *testing.T) {
+	http := []string{"http", "https"}
+
+	tests := []struct {
+		name         string
+		kong2        bool
+		route, other *client.Route
+		conflict     string
+	}{
+		{
+			name:     "identical",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{"/users"}, Methods: []string{"GET"}},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/users"}, Methods: []string{"GET"}},
+			conflict: "route a matches the same requests as route b",
+		},
+		{
+			name:     "identical in another order",
+			route:    &client.Route{Name: "a", Protocols: http, Hosts: []string{"a.example.com", "b.example.com"}},
+			other:    &client.Route{Name: "b", Protocols: []string{"https", "http"}, Hosts: []string{"b.example.com", "a.example.com"}},
+			conflict: "route a matches the same requests as route b",
+		},
+		{
+			name:     "not shadowed: the route evaluated first requires methods",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{"/users"}, Hosts: []string{"api.example.com"}, CreatedAt: 2},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/"}, Hosts: []string{"api.example.com"}, Methods: []string{"GET", "POST"}, CreatedAt: 1},
+			conflict: "",
+		},
+		{
+			name:     "not shadowed: the route evaluated first requires a header",
+			route:    &client.Route{Name: "a", Protocols: http, Hosts: []string{"api.example.com"}, Paths: []string{"/users"}},
+			other:    &client.Route{Name: "b", Protocols: http, Hosts: []string{"api.example.com"}, Paths: []string{"/"}, Headers: map[string][]string{"x-version": {"2"}}},
+			conflict: "",
+		},
+		{
+			name:     "not shadowing: the narrower route is evaluated first",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{"/users"}, CreatedAt: 1},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/users/admin"}, Methods: []string{"GET"}, CreatedAt: 2},
+			conflict: "",
+		},
+		{
+			name:     "not shadowed: the longer path is evaluated first",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{"/users"}, CreatedAt: 1},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/user"}, CreatedAt: 2},
+			conflict: "",
+		},
+		{
+			name:     "not shadowed: the route evaluated first requires a host",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{"/users/admin"}, Methods: []string{"GET"}},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/users"}, Methods: []string{"GET", "POST"}, Hosts: []string{"*"}},
+			conflict: "",
+		},
+		{
+			name:     "not shadowed: the wildcard host route requires a header",
+			route:    &client.Route{Name: "a", Protocols: http, Hosts: []string{"api.example.com"}},
+			other:    &client.Route{Name: "b", Protocols: http, Hosts: []string{"*.example.com"}, Headers: map[string][]string{"x-version": {"2"}}},
+			conflict: "",
+		},
+		{
+			name:     "shadows with a longer plain path",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{"/users", "/users/admin/settings"}, CreatedAt: 2},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/users/admin"}, CreatedAt: 1},
+			conflict: "route a shadows route b",
+		},
+		{
+			name:     "not shadowed: the route evaluated first requires hosts",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{"/users"}, Methods: []string{"GET"}, CreatedAt: 2},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/users"}, Hosts: []string{"*.example.com", "example.com"}, Methods: []string{"GET", "POST"}, CreatedAt: 1},
+			conflict: "",
+		},
+		{
+			name:     "shadowed by fewer headers values",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{"/users"}, Headers: map[string][]string{"x-version": {"2"}}, CreatedAt: 2},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/users"}, Headers: map[string][]string{"X-Version": {"1", "2"}}, CreatedAt: 1},
+			conflict: "route a is shadowed by route b, which matches every request it matches and is evaluated first as it was created first",
+		},
+		{
+			name:     "not shadowed: regex paths only compared as strings",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{`~/users/\d+$`}, CreatedAt: 2},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{`~/users/.*`}, CreatedAt: 1},
+			conflict: "",
+		},
+		{
+			name:     "shadowed by a plain prefix",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{"/users/admin"}, CreatedAt: 2},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/users/admin/settings", "/users"}, CreatedAt: 1},
+			conflict: "route a is shadowed by route b, which matches every request it matches and is evaluated first as its longest path has 21 characters against 12",
+		},
+		{
+			name:     "not shadowed: other protocols",
+			route:    &client.Route{Name: "a", Protocols: []string{"grpc", "grpcs"}, Paths: []string{"/users"}},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/users"}},
+			conflict: "",
+		},
+		{
+			name:     "not shadowed: distinct hosts",
+			route:    &client.Route{Name: "a", Protocols: http, Hosts: []string{"a.example.com"}},
+			other:    &client.Route{Name: "b", Protocols: http, Hosts: []string{"b.example.com"}},
+			conflict: "",
+		},
+		{
+			name:     "not compared: expression route",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{"/users"}},
+			other:    &client.Route{Name: "b", Protocols: http, Expression: `http.path ^= "/users"`},
+			conflict: "",
+		},
+		{
+			name:     "not compared: stream routes",
+			route:    &client.Route{Name: "a", Protocols: []string{"tcp"}, Destinations: []*client.RouteEndpoint{{Port: 80}}},
+			other:    &client.Route{Name: "b", Protocols: []string{"tcp"}, Destinations: []*client.RouteEndpoint{{Port: 80}}},
+			conflict: "",
+		},
+		{
+			name:     "shadows with a regex path before Kong 3",
+			kong2:    true,
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{`/users/\d+`, "/users"}, CreatedAt: 2},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/users"}, CreatedAt: 1},
+			conflict: "route a shadows route b, matching every request it matches and being evaluated first as it has a regex path",
+		},
+		{
+			name:     "shadows with a longer path since Kong 3",
+			route:    &client.Route{Name: "a", Protocols: http, Paths: []string{`/users/\d+`, "/users"}, CreatedAt: 2},
+			other:    &client.Route{Name: "b", Protocols: http, Paths: []string{"/users"}, CreatedAt: 1},
+			conflict: "route a shadows route b, matching every request it matches and being evaluated first as its longest path has 10 characters against 6",
+		},
+		{
+			name:     "unnamed routes",
+			route:    &client.Route{Protocols: http, Paths: []string{"/users"}},
+			other:    &client.Route{ID: "f0c6a1a2", Protocols: http, Paths: []string{"/users"}},
+			conflict: "route (unnamed) matches the same requests as route f0c6a1a2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if conflict := routeConflict(test.route, test.other, !test.kong2); !strings.HasPrefix(conflict, test.conflict) || (test.conflict == "") != (conflict == "") {
+				t.Errorf("routeConflict() = %q, want %q", conflict, test.conflict)
+			}
+		})
+	}
+}
+
+func TestCheckRouteConflicts(t *testing.T) {
+	meta := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = io.WriteString(w, `{"version":"3.4.0","configuration":{"router_flavor":"traditional_compatible"}}`)
+		case "/routes/", "/routes":
+			_, _ = io.WriteString(w, `{"data":[{"id":"existing","name":"existing","protocols":["http","https"],"hosts":["api.example.com"],"paths":["/"],"created_at":1},{"id":"legacy","name":"legacy","protocols":["http","https"],"hosts":["legacy.example.com"],"paths":["/legacy"],"created_at":2}],"next":null}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}, map[string]interface{}{"strict_routing": true})
+
+	route := resourceKongRoute()
+
+	users := map[string]cty.Value{
+		"protocols": cty.ListVal([]cty.Value{cty.StringVal("http"), cty.StringVal("https")}),
+		"paths":     cty.ListVal([]cty.Value{cty.StringVal("/users")}),
+	}
+	existing := map[string]cty.Value{
+		"name":      cty.StringVal("existing"),
+		"protocols": cty.ListVal([]cty.Value{cty.StringVal("http"), cty.StringVal("https")}),
+		"hosts":     cty.ListVal([]cty.Value{cty.StringVal("api.example.com")}),
+		"paths":     cty.ListVal([]cty.Value{cty.StringVal("/")}),
+	}
+	legacy := map[string]cty.Value{
+		"name":      cty.StringVal("legacy"),
+		"protocols": cty.ListVal([]cty.Value{cty.StringVal("http"), cty.StringVal("https")}),
+		"hosts":     cty.ListVal([]cty.Value{cty.StringVal("legacy.example.com")}),
+		"paths":     cty.ListVal([]cty.Value{cty.StringVal("/legacy")}),
+	}
+	unnamedLegacy := map[string]cty.Value{}
+	for name, value := range legacy {
+		if name != "name" {
+			unnamedLegacy[name] = value
+		}
+	}
+	moved := map[string]cty.Value{"workspace": cty.StringVal("team-b")}
+	for name, value := range existing {
+		moved[name] = value
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		state    map[string]string
+		config   map[string]cty.Value
+		conflict string
+	}{
+		{"unnamed route", "", nil, users, ""},
+		{"identical unnamed route", "", nil, users, "route (unnamed) matches the same requests as route (unnamed)"},
+		{"existing route", "existing", nil, existing, ""},
+		{"existing route planned again", "existing", nil, existing, ""},
+		{"existing route moving to another workspace", "existing", map[string]string{"workspace": "default"}, moved, ""},
+		{"existing route replaced", "", nil, existing, ""},
+		{"route moved to another address", "", nil, legacy, ""},
+		{"unnamed copy of a route", "", nil, unnamedLegacy, "route (unnamed) matches the same requests as route legacy"},
+	}
+
+	for _, test := range tests {
+		_, err := testPlan(route, meta, test.id, test.state, test.config)
+
+		switch {
+		case test.conflict == "" && err != nil:
+			t.Errorf("%s: %s, want no conflict", test.name, err)
+		case test.conflict != "" && (err == nil || !strings.Contains(err.Error(), test.conflict)):
+			t.Errorf("%s: %v, want %q", test.name, err, test.conflict)
+		}
+	}
+}
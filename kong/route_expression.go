@@ -0,0 +1,442 @@
+package kong
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Types of the values compared by route expressions.
+const (
+	expressionString = "string"
+	expressionInt    = "int"
+	expressionIP     = "ip"
+)
+
+// expressionFields maps the fields known to the expressions router to their
+// type. The fields of expressionFieldPrefixes are followed by a header name,
+// a query argument name or a path segment index.
+var expressionFields = map[string]string{
+	"net.protocol":           expressionString,
+	"tls.sni":                expressionString,
+	"http.method":            expressionString,
+	"http.host":              expressionString,
+	"http.path":              expressionString,
+	"http.path.segments.len": expressionInt,
+	"net.src.ip":             expressionIP,
+	"net.src.port":           expressionInt,
+	"net.dst.ip":             expressionIP,
+	"net.dst.port":           expressionInt,
+}
+
+var expressionFieldPrefixes = map[string]*regexp.Regexp{
+	"http.headers.":       regexp.MustCompile(`^[a-z0-9_]+$`),
+	"http.queries.":       regexp.MustCompile(`^[A-Za-z0-9_]+$`),
+	"http.path.segments.": regexp.MustCompile(`^[0-9]+(_[0-9]+)?$`),
+}
+
+// expressionOperators maps the operators of the expressions router to the
+// types of the fields they apply to.
+var expressionOperators = map[string][]string{
+	"==":       {expressionString, expressionInt, expressionIP},
+	"!=":       {expressionString, expressionInt, expressionIP},
+	"~":        {expressionString},
+	"^=":       {expressionString},
+	"=^":       {expressionString},
+	"contains": {expressionString},
+	">":        {expressionInt},
+	">=":       {expressionInt},
+	"<":        {expressionInt},
+	"<=":       {expressionInt},
+	"in":       {expressionIP},
+	"not in":   {expressionIP},
+}
+
+// validateRouteExpression checks the syntax of a route expression as parsed
+// by the expressions router of Kong 3.x, along with the fields, operators and
+// regexes it uses.
+func validateRouteExpression(v interface{}, k string) ([]string, []error) {
+	expression, _ := v.(string)
+
+	if err := parseRouteExpression(expression); err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid route expression: %w", k, err)}
+	}
+
+	return nil, nil
+}
+
+func parseRouteExpression(expression string) error {
+	tokens, err := lexRouteExpression(expression)
+	if err != nil {
+		return err
+	}
+
+	p := &expressionParser{tokens: tokens}
+	if err := p.parseOr(); err != nil {
+		return err
+	}
+	if !p.done() {
+		return p.errorf("unexpected %s", p.peek().text)
+	}
+
+	return nil
+}
+
+const (
+	tokenField = iota
+	tokenString
+	tokenInt
+	tokenIP
+	tokenOperator
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type expressionToken struct {
+	kind     int
+	text     string
+	value    string
+	position int
+}
+
+// lexRouteExpression splits an expression into tokens.
+func lexRouteExpression(expression string) ([]expressionToken, error) {
+	var tokens []expressionToken
+
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		start := i
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+
+		case c == '(' || c == ')':
+			kind := tokenOpen
+			if c == ')' {
+				kind = tokenClose
+			}
+			tokens = append(tokens, expressionToken{kind: kind, text: string(c), position: start})
+			i++
+			continue
+
+		case strings.HasPrefix(expression[i:], "&&"):
+			tokens = append(tokens, expressionToken{kind: tokenAnd, text: "&&", position: start})
+			i += 2
+			continue
+
+		case strings.HasPrefix(expression[i:], "||"):
+			tokens = append(tokens, expressionToken{kind: tokenOr, text: "||", position: start})
+			i += 2
+			continue
+
+		case c == '"':
+			value, n, err := lexString(expression[i:])
+			if err != nil {
+				return nil, fmt.Errorf("at character %d: %w", start+1, err)
+			}
+			tokens = append(tokens, expressionToken{kind: tokenString, text: expression[i : i+n], value: value, position: start})
+			i += n
+			continue
+
+		case strings.HasPrefix(expression[i:], "r#\""):
+			end := strings.Index(expression[i+3:], "\"#")
+			if end < 0 {
+				return nil, fmt.Errorf("at character %d: unterminated raw string", start+1)
+			}
+			n := 3 + end + 2
+			tokens = append(tokens, expressionToken{kind: tokenString, text: expression[i : i+n], value: expression[i+3 : i+3+end], position: start})
+			i += n
+			continue
+		}
+
+		for _, operator := range []string{"==", "!=", "^=", "=^", ">=", "<=", "~", ">", "<"} {
+			if strings.HasPrefix(expression[i:], operator) {
+				tokens = append(tokens, expressionToken{kind: tokenOperator, text: operator, position: start})
+				i += len(operator)
+				break
+			}
+		}
+		if i > start {
+			continue
+		}
+
+		if c == '!' {
+			tokens = append(tokens, expressionToken{kind: tokenNot, text: "!", position: start})
+			i++
+			continue
+		}
+
+		for i < len(expression) && isExpressionWordCharacter(expression[i]) {
+			i++
+		}
+		if i == start {
+			return nil, fmt.Errorf("at character %d: unexpected %q", start+1, c)
+		}
+
+		token, err := classifyExpressionWord(expression[start:i], start)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	// "not in" is the only operator made of two words.
+	merged := tokens[:0]
+	for _, token := range tokens {
+		if token.kind == tokenOperator && token.text == "in" && len(merged) > 0 && merged[len(merged)-1].kind == tokenField && merged[len(merged)-1].text == "not" {
+			merged[len(merged)-1] = expressionToken{kind: tokenOperator, text: "not in", position: merged[len(merged)-1].position}
+			continue
+		}
+		merged = append(merged, token)
+	}
+
+	return merged, nil
+}
+
+func isExpressionWordCharacter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("_.:/-", c) >= 0
+}
+
+// classifyExpressionWord tells apart fields, integers and IP addresses.
+func classifyExpressionWord(word string, position int) (expressionToken, error) {
+	switch word {
+	case "in", "contains":
+		return expressionToken{kind: tokenOperator, text: word, position: position}, nil
+	}
+
+	// IPv6 addresses may start with a letter, like fields.
+	if net.ParseIP(word) != nil {
+		return expressionToken{kind: tokenIP, text: word, position: position}, nil
+	}
+	if _, _, err := net.ParseCIDR(word); err == nil {
+		return expressionToken{kind: tokenIP, text: word, position: position}, nil
+	}
+
+	if c := word[0]; c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' {
+		return expressionToken{kind: tokenField, text: word, position: position}, nil
+	}
+	if _, err := strconv.ParseInt(word, 0, 64); err == nil {
+		return expressionToken{kind: tokenInt, text: word, position: position}, nil
+	}
+
+	return expressionToken{}, fmt.Errorf("at character %d: %q is neither an integer nor an IP address", position+1, word)
+}
+
+// lexString reads the quoted string at the start of s and returns its value
+// along with its length.
+func lexString(s string) (string, int, error) {
+	var value strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return value.String(), i + 1, nil
+		case '\\':
+			i++
+			if i == len(s) {
+				break
+			}
+			switch s[i] {
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			case 't':
+				value.WriteByte('\t')
+			case '\\', '"':
+				value.WriteByte(s[i])
+			default:
+				return "", 0, fmt.Errorf("unknown escape sequence \\%c", s[i])
+			}
+		default:
+			value.WriteByte(s[i])
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+// expressionParser is a recursive descent parser of the grammar:
+//
+//	or        = and { "||" and }
+//	and       = term { "&&" term }
+//	term      = [ "!" ] "(" or ")" | predicate
+//	predicate = lhs operator value
+//	lhs       = field | "lower" "(" field ")"
+type expressionParser struct {
+	tokens []expressionToken
+	next   int
+}
+
+func (p *expressionParser) done() bool {
+	return p.next >= len(p.tokens)
+}
+
+func (p *expressionParser) peek() expressionToken {
+	return p.tokens[p.next]
+}
+
+func (p *expressionParser) errorf(format string, args ...interface{}) error {
+	if p.done() {
+		return fmt.Errorf("unexpected end of expression, "+format, args...)
+	}
+	return fmt.Errorf("at character %d: "+format, append([]interface{}{p.peek().position + 1}, args...)...)
+}
+
+func (p *expressionParser) accept(kind int) bool {
+	if !p.done() && p.peek().kind == kind {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *expressionParser) parseOr() error {
+	if err := p.parseAnd(); err != nil {
+		return err
+	}
+	for p.accept(tokenOr) {
+		if err := p.parseAnd(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *expressionParser) parseAnd() error {
+	if err := p.parseTerm(); err != nil {
+		return err
+	}
+	for p.accept(tokenAnd) {
+		if err := p.parseTerm(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *expressionParser) parseTerm() error {
+	not := p.accept(tokenNot)
+
+	if p.accept(tokenOpen) {
+		if err := p.parseOr(); err != nil {
+			return err
+		}
+		if !p.accept(tokenClose) {
+			return p.errorf("expected )")
+		}
+		return nil
+	}
+
+	if not {
+		return p.errorf("expected ( after !")
+	}
+
+	return p.parsePredicate()
+}
+
+func (p *expressionParser) parsePredicate() error {
+	if p.done() || p.peek().kind != tokenField {
+		return p.errorf("expected a field")
+	}
+
+	field := p.peek()
+	p.next++
+
+	lower := false
+	if field.text == "lower" && p.accept(tokenOpen) {
+		if p.done() || p.peek().kind != tokenField {
+			return p.errorf("expected a field")
+		}
+		field = p.peek()
+		p.next++
+		if !p.accept(tokenClose) {
+			return p.errorf("expected )")
+		}
+		lower = true
+	}
+
+	fieldType, err := expressionFieldType(field.text)
+	if err != nil {
+		return fmt.Errorf("at character %d: %w", field.position+1, err)
+	}
+	if lower && fieldType != expressionString {
+		return fmt.Errorf("at character %d: lower only applies to string fields, %s is not one", field.position+1, field.text)
+	}
+
+	if p.done() || p.peek().kind != tokenOperator {
+		return p.errorf("expected an operator after %s", field.text)
+	}
+	operator := p.peek()
+	p.next++
+
+	if !containsString(expressionOperators[operator.text], fieldType) {
+		return fmt.Errorf("at character %d: operator %s doesn't apply to the %s field %s", operator.position+1, operator.text, fieldType, field.text)
+	}
+
+	if p.done() {
+		return p.errorf("expected a value after %s", operator.text)
+	}
+	value := p.peek()
+	p.next++
+
+	switch fieldType {
+	case expressionString:
+		if value.kind != tokenString {
+			return fmt.Errorf("at character %d: %s expects a quoted string", value.position+1, field.text)
+		}
+		if operator.text == "~" {
+			if _, err := regexp.Compile(value.value); err != nil {
+				return fmt.Errorf("at character %d: invalid regex: %w", value.position+1, err)
+			}
+		}
+	case expressionInt:
+		if value.kind != tokenInt {
+			return fmt.Errorf("at character %d: %s expects an integer", value.position+1, field.text)
+		}
+	case expressionIP:
+		if value.kind != tokenIP {
+			return fmt.Errorf("at character %d: %s expects an IP address or a CIDR range", value.position+1, field.text)
+		}
+		_, _, err := net.ParseCIDR(value.text)
+		if cidr := err == nil; cidr != (operator.text == "in" || operator.text == "not in") {
+			return fmt.Errorf("at character %d: in and not in compare %s to CIDR ranges, == and != to IP addresses", value.position+1, field.text)
+		}
+	}
+
+	return nil
+}
+
+// expressionFieldType returns the type of the given field.
+func expressionFieldType(field string) (string, error) {
+	if fieldType, ok := expressionFields[field]; ok {
+		return fieldType, nil
+	}
+
+	for prefix, name := range expressionFieldPrefixes {
+		if strings.HasPrefix(field, prefix) {
+			if !name.MatchString(strings.TrimPrefix(field, prefix)) {
+				return "", fmt.Errorf("invalid field %s", field)
+			}
+			return expressionString, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown field %s", field)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
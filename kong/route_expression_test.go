@@ -0,0 +1,70 @@
+package kong
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRouteExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{`http.path == "/foo"`, ""},
+		{`http.path ^= "/foo" && http.method == "GET"`, ""},
+		{`http.host == "example.com" || http.host =^ ".example.com"`, ""},
+		{`(http.path ^= "/a" || http.path ^= "/b") && !(http.method == "POST")`, ""},
+		{`lower(http.path) contains "admin"`, ""},
+		{`http.path ~ "^/users/\\d+$"`, ""},
+		{`http.path ~ r#"^/users/\d+$"#`, ""},
+		{`http.headers.x_version == "2"`, ""},
+		{`http.queries.page == "1"`, ""},
+		{`http.path.segments.0 == "api" && http.path.segments.1_2 == "v1/users"`, ""},
+		{`http.path.segments.len > 2`, ""},
+		{`net.protocol == "https" && tls.sni == "example.com"`, ""},
+		{`net.src.ip in 10.0.0.0/8 && net.src.port >= 1024`, ""},
+		{`net.dst.ip == 192.168.0.1`, ""},
+		{`net.src.ip not in fd00::/8`, ""},
+		{`net.dst.ip == fe80::1`, ""},
+		{`http.path == "a\"b"`, ""},
+
+		{``, "unexpected end of expression"},
+		{`http.path`, "expected an operator after http.path"},
+		{`http.path ==`, "unexpected end of expression"},
+		{`http.path == "/foo" &&`, "unexpected end of expression"},
+		{`http.path == "/foo" http.method == "GET"`, "at character 21"},
+		{`(http.path == "/foo"`, "expected )"},
+		{`!http.path == "/foo"`, "expected ( after !"},
+		{`http.pth == "/foo"`, "unknown field http.pth"},
+		{`http.headers.X-Version == "2"`, "invalid field http.headers.X-Version"},
+		{`http.headers.X_Version == "2"`, "invalid field http.headers.X_Version"},
+		{`http.path.segments.a == "api"`, "invalid field http.path.segments.a"},
+		{`http.path > 2`, "operator > doesn't apply to the string field http.path"},
+		{`net.src.port ~ "80"`, "operator ~ doesn't apply to the int field net.src.port"},
+		{`lower(net.src.port) == 80`, "lower only applies to string fields"},
+		{`http.path == /foo`, "\"/foo\" is neither an integer nor an IP address"},
+		{`http.path == 42`, "http.path expects a quoted string"},
+		{`net.src.port == "80"`, "net.src.port expects an integer"},
+		{`net.src.ip == "10.0.0.1"`, "net.src.ip expects an IP address or a CIDR range"},
+		{`net.src.ip in 10.0.0.1`, "in and not in compare net.src.ip to CIDR ranges"},
+		{`net.src.ip == 10.0.0.0/8`, "== and != to IP addresses"},
+		{`http.path ~ "(unclosed"`, "invalid regex"},
+		{`http.path == "/foo`, "unterminated string"},
+		{`http.path == r#"/foo`, "unterminated raw string"},
+		{`http.path == "\q"`, "unknown escape sequence \\q"},
+		{`http.path == "/foo" & http.method == "GET"`, "at character 21: unexpected '&'"},
+	}
+
+	for _, test := range tests {
+		err := parseRouteExpression(test.expression)
+
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("parseRouteExpression(%q) = %q, want no error", test.expression, err)
+		case test.err != "" && err == nil:
+			t.Errorf("parseRouteExpression(%q) succeeded, want an error containing %q", test.expression, test.err)
+		case test.err != "" && !strings.Contains(err.Error(), test.err):
+			t.Errorf("parseRouteExpression(%q) = %q, want an error containing %q", test.expression, err, test.err)
+		}
+	}
+}
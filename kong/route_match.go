@@ -0,0 +1,353 @@
+package kong
+
+import (
+	"fmt"
+	"math/bits"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+)
+
+// The traditional router of Kong sorts the routes into categories by the
+// matchers they set, the categories with more matchers being evaluated first,
+// then within a category by the criteria of routeMatcherCriteria. The first
+// route matching the request wins.
+
+// Matchers of the traditional router, the order of the bits ranking the
+// categories setting as many matchers.
+const (
+	matchDestination = 1 << iota
+	matchSource
+	matchSNI
+	matchMethod
+	matchPath
+	matchHeader
+	matchHost
+)
+
+var matchNames = []struct {
+	bit  int
+	name string
+}{
+	{matchHost, "hosts"},
+	{matchHeader, "header"},
+	{matchPath, "paths"},
+	{matchMethod, "methods"},
+	{matchSNI, "snis"},
+	{matchSource, "source"},
+	{matchDestination, "destination"},
+}
+
+// Traits ranking the routes of a category, the order of the bits giving
+// their weight.
+const (
+	submatchRegexPath = 1 << iota
+	submatchPlainHostsOnly
+	submatchWildcardHostPort
+)
+
+// routeRequest is a request simulated against the routes.
+type routeRequest struct {
+	Protocol string
+	Method   string
+	Host     string
+	Path     string
+	SNI      string
+	Headers  map[string]string
+}
+
+// routeMatcher holds what the router derives from a route to rank it.
+type routeMatcher struct {
+	route         *client.Route
+	v3            bool
+	matches       int
+	submatches    int
+	maxPathLength int // of the plain paths
+}
+
+// routeMatch is a route matching a request.
+type routeMatch struct {
+	*routeMatcher
+	path     string
+	redirect bool
+}
+
+// routeMatcherCriteria rank the routes of a category, the routes with the
+// highest key being evaluated first.
+var routeMatcherCriteria = []struct {
+	key      func(m *routeMatcher) int
+	describe func(first, second *routeMatcher) string
+}{
+	{
+		key: func(m *routeMatcher) int { return bits.OnesCount(uint(m.matches)) },
+		describe: func(first, second *routeMatcher) string {
+			return fmt.Sprintf("it sets %d matchers against %d", bits.OnesCount(uint(first.matches)), bits.OnesCount(uint(second.matches)))
+		},
+	},
+	{
+		key: func(m *routeMatcher) int { return m.matches },
+		describe: func(first, second *routeMatcher) string {
+			return fmt.Sprintf("routes matching on %s are evaluated before routes matching on %s", first.matchNames(), second.matchNames())
+		},
+	},
+	{
+		key: func(m *routeMatcher) int { return m.submatches },
+		describe: func(first, second *routeMatcher) string {
+			switch bit := 1 << (bits.Len(uint(first.submatches^second.submatches)) - 1); bit {
+			case submatchWildcardHostPort:
+				return "its wildcard hosts carry a port"
+			case submatchPlainHostsOnly:
+				return "its hosts have no wildcard"
+			default:
+				return "it has a regex path"
+			}
+		},
+	},
+	{
+		key: func(m *routeMatcher) int { return len(m.route.Headers) },
+		describe: func(first, second *routeMatcher) string {
+			return fmt.Sprintf("it matches %d headers against %d", len(first.route.Headers), len(second.route.Headers))
+		},
+	},
+	{
+		key: func(m *routeMatcher) int {
+			if m.submatches&submatchRegexPath == 0 {
+				return 0
+			}
+			return m.route.RegexPriority
+		},
+		describe: func(first, second *routeMatcher) string {
+			return fmt.Sprintf("its regex_priority is %d against %d", first.route.RegexPriority, second.route.RegexPriority)
+		},
+	},
+	{
+		key: func(m *routeMatcher) int { return m.maxPathLength },
+		describe: func(first, second *routeMatcher) string {
+			return fmt.Sprintf("its longest path has %d characters against %d", first.maxPathLength, second.maxPathLength)
+		},
+	},
+	{
+		key: func(m *routeMatcher) int { return -m.route.CreatedAt },
+		describe: func(first, second *routeMatcher) string {
+			return "it was created first"
+		},
+	},
+}
+
+func newRouteMatcher(route *client.Route, v3 bool) *routeMatcher {
+	m := &routeMatcher{route: route, v3: v3}
+
+	if len(route.Hosts) > 0 {
+		m.matches |= matchHost
+		m.submatches |= submatchPlainHostsOnly
+		for _, host := range route.Hosts {
+			if strings.Contains(host, "*") {
+				m.submatches &^= submatchPlainHostsOnly
+				if strings.Contains(host, ":") {
+					m.submatches |= submatchWildcardHostPort
+				}
+			}
+		}
+	}
+
+	if len(route.Headers) > 0 {
+		m.matches |= matchHeader
+	}
+
+	if len(route.Paths) > 0 {
+		m.matches |= matchPath
+		for _, path := range route.Paths {
+			// Only plain paths count towards the longest path.
+			if m.isRegexPath(path) {
+				m.submatches |= submatchRegexPath
+			} else if len(path) > m.maxPathLength {
+				m.maxPathLength = len(path)
+			}
+		}
+	}
+
+	if len(route.Methods) > 0 {
+		m.matches |= matchMethod
+	}
+	if len(route.SNIs) > 0 {
+		m.matches |= matchSNI
+	}
+	if len(route.Sources) > 0 {
+		m.matches |= matchSource
+	}
+	if len(route.Destinations) > 0 {
+		m.matches |= matchDestination
+	}
+
+	return m
+}
+
+// name returns the name of the route, its id when it has none.
+func (m *routeMatcher) name() string {
+	if m.route.Name != "" {
+		return m.route.Name
+	}
+	return m.route.ID
+}
+
+func (m *routeMatcher) matchNames() string {
+	var names []string
+	for _, match := range matchNames {
+		if m.matches&match.bit != 0 {
+			names = append(names, match.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func (m *routeMatcher) isRegexPath(path string) bool {
+	if m.v3 {
+		return strings.HasPrefix(path, "~")
+	}
+	return strings.ContainsAny(path, regexPathCharacters)
+}
+
+// compareRouteMatchers reports whether the router evaluates a before b, and
+// why the first of the two is evaluated first.
+func compareRouteMatchers(a, b *routeMatcher) (bool, string) {
+	for _, criterion := range routeMatcherCriteria {
+		ka, kb := criterion.key(a), criterion.key(b)
+		if ka > kb {
+			return true, criterion.describe(a, b)
+		} else if ka < kb {
+			return false, criterion.describe(b, a)
+		}
+	}
+
+	return false, ""
+}
+
+// match returns how the route matches the request, nil when it doesn't.
+func (m *routeMatcher) match(request *routeRequest) *routeMatch {
+	route := m.route
+	if route.Expression != "" {
+		return nil
+	}
+
+	match := &routeMatch{routeMatcher: m}
+
+	switch {
+	case containsString(route.Protocols, request.Protocol):
+	case request.Protocol == "http" && containsString(route.Protocols, "https"),
+		request.Protocol == "grpc" && containsString(route.Protocols, "grpcs"):
+		match.redirect = true
+	default:
+		return nil
+	}
+
+	if len(route.Hosts) > 0 && !anyString(route.Hosts, func(host string) bool { return matchRouteHost(host, request.Host) }) {
+		return nil
+	}
+
+	for name, values := range route.Headers {
+		value, ok := requestHeader(request.Headers, name)
+		if !ok || !anyString(values, func(v string) bool { return m.matchHeaderValue(v, value) }) {
+			return nil
+		}
+	}
+
+	if len(route.Paths) > 0 {
+		for _, path := range route.Paths {
+			if m.matchPath(path, request.Path) {
+				match.path = path
+				break
+			}
+		}
+		if match.path == "" {
+			return nil
+		}
+	}
+
+	if len(route.Methods) > 0 && !anyString(route.Methods, func(method string) bool { return strings.EqualFold(method, request.Method) }) {
+		return nil
+	}
+
+	if len(route.SNIs) > 0 && !anyString(route.SNIs, func(sni string) bool { return strings.EqualFold(sni, request.SNI) }) {
+		return nil
+	}
+
+	// Stream routes never match HTTP requests.
+	if len(route.Sources) > 0 || len(route.Destinations) > 0 {
+		return nil
+	}
+
+	return match
+}
+
+func (m *routeMatcher) matchPath(path, requested string) bool {
+	if !m.isRegexPath(path) {
+		return strings.HasPrefix(requested, path)
+	}
+
+	regex, err := regexp.Compile("^(?:" + strings.TrimPrefix(path, "~") + ")")
+	return err == nil && regex.MatchString(requested)
+}
+
+func (m *routeMatcher) matchHeaderValue(value, requested string) bool {
+	if m.v3 && strings.HasPrefix(value, "~*") {
+		regex, err := regexp.Compile("(?i)" + strings.TrimPrefix(value, "~*"))
+		return err == nil && regex.MatchString(requested)
+	}
+
+	return strings.EqualFold(value, requested)
+}
+
+// matchRouteHost matches the host of a request against the host of a route,
+// which may start or end with a wildcard and only matches the port of the
+// request when it sets one.
+func matchRouteHost(pattern, host string) bool {
+	pattern, host = strings.ToLower(pattern), strings.ToLower(host)
+
+	if _, _, err := net.SplitHostPort(pattern); err != nil {
+		if name, _, err := net.SplitHostPort(host); err == nil {
+			host = name
+		}
+	}
+
+	regex := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".+") + "$"
+	return regexp.MustCompile(regex).MatchString(host)
+}
+
+func requestHeader(headers map[string]string, name string) (string, bool) {
+	for n, value := range headers {
+		if strings.EqualFold(n, name) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func anyString(values []string, f func(string) bool) bool {
+	for _, value := range values {
+		if f(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRouteRequest returns the routes matching the request in the order the
+// router evaluates them, the first one being selected.
+func matchRouteRequest(routes []*client.Route, v3 bool, request *routeRequest) []*routeMatch {
+	var matches []*routeMatch
+	for _, route := range routes {
+		if match := newRouteMatcher(route, v3).match(request); match != nil {
+			matches = append(matches, match)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		before, _ := compareRouteMatchers(matches[i].routeMatcher, matches[j].routeMatcher)
+		return before
+	})
+
+	return matches
+}
@@ -0,0 +1,171 @@
+package kong
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+)
+
+func TestCompareRouteMatchers(t *testing.T) {
+	tests := []struct {
+		name  string
+		v3    bool
+		first *client.Route
+		then  *client.Route
+		why   string
+	}{
+		{
+			name:  "more matchers",
+			first: &client.Route{Hosts: []string{"example.com"}, Paths: []string{"/"}},
+			then:  &client.Route{Paths: []string{"/users/admin"}},
+			why:   "it sets 2 matchers against 1",
+		},
+		{
+			name:  "hosts before headers",
+			first: &client.Route{Hosts: []string{"example.com"}},
+			then:  &client.Route{Headers: map[string][]string{"x-version": {"2"}}},
+			why:   "routes matching on hosts are evaluated before routes matching on header",
+		},
+		{
+			name:  "headers before paths",
+			first: &client.Route{Headers: map[string][]string{"x-version": {"2"}}},
+			then:  &client.Route{Paths: []string{"/"}},
+			why:   "routes matching on header are evaluated before routes matching on paths",
+		},
+		{
+			name:  "paths before methods",
+			first: &client.Route{Paths: []string{"/"}},
+			then:  &client.Route{Methods: []string{"GET"}},
+			why:   "routes matching on paths are evaluated before routes matching on methods",
+		},
+		{
+			name:  "plain hosts before wildcard hosts",
+			first: &client.Route{Hosts: []string{"api.example.com"}},
+			then:  &client.Route{Hosts: []string{"*.example.com"}},
+			why:   "its hosts have no wildcard",
+		},
+		{
+			name:  "wildcard hosts with a port first",
+			first: &client.Route{Hosts: []string{"*.example.com:8443"}},
+			then:  &client.Route{Hosts: []string{"*.example.com"}},
+			why:   "its wildcard hosts carry a port",
+		},
+		{
+			name:  "regex paths before plain paths",
+			v3:    true,
+			first: &client.Route{Paths: []string{`~/users/\d+`}},
+			then:  &client.Route{Paths: []string{"/users/admin/settings"}},
+			why:   "it has a regex path",
+		},
+		{
+			name:  "regex paths without prefix before Kong 3",
+			first: &client.Route{Paths: []string{`/users/\d+`}},
+			then:  &client.Route{Paths: []string{"/users/admin/settings"}},
+			why:   "it has a regex path",
+		},
+		{
+			name:  "more headers",
+			first: &client.Route{Headers: map[string][]string{"x-version": {"2"}, "x-tenant": {"acme"}}},
+			then:  &client.Route{Headers: map[string][]string{"x-version": {"2"}}},
+			why:   "it matches 2 headers against 1",
+		},
+		{
+			name:  "regex_priority of regex routes",
+			v3:    true,
+			first: &client.Route{Paths: []string{`~/a`}, RegexPriority: 10, CreatedAt: 2},
+			then:  &client.Route{Paths: []string{`~/abcdef`}, RegexPriority: 0, CreatedAt: 1},
+			why:   "its regex_priority is 10 against 0",
+		},
+		{
+			name:  "regex_priority ignored on plain routes",
+			v3:    true,
+			first: &client.Route{Paths: []string{"/b"}, RegexPriority: 0, CreatedAt: 1},
+			then:  &client.Route{Paths: []string{"/a"}, RegexPriority: 10, CreatedAt: 2},
+			why:   "it was created first",
+		},
+		{
+			name:  "longer plain path",
+			v3:    true,
+			first: &client.Route{Paths: []string{"/users/admin"}},
+			then:  &client.Route{Paths: []string{"/users"}},
+			why:   "its longest path has 12 characters against 6",
+		},
+		{
+			name:  "regex paths don't count towards the longest path",
+			v3:    true,
+			first: &client.Route{Paths: []string{`~/a`, "/users"}, CreatedAt: 2},
+			then:  &client.Route{Paths: []string{`~/a/very/long/regex/path`}, CreatedAt: 1},
+			why:   "its longest path has 6 characters against 0",
+		},
+		{
+			name:  "created first",
+			v3:    true,
+			first: &client.Route{Paths: []string{"/users"}, CreatedAt: 1},
+			then:  &client.Route{Paths: []string{"/items"}, CreatedAt: 2},
+			why:   "it was created first",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, then := newRouteMatcher(test.first, test.v3), newRouteMatcher(test.then, test.v3)
+
+			before, why := compareRouteMatchers(first, then)
+			if !before || why != test.why {
+				t.Errorf("compareRouteMatchers(first, then) = %v, %q, want true, %q", before, why, test.why)
+			}
+
+			before, why = compareRouteMatchers(then, first)
+			if before || why != test.why {
+				t.Errorf("compareRouteMatchers(then, first) = %v, %q, want false, %q", before, why, test.why)
+			}
+		})
+	}
+}
+
+func TestMatchRouteRequest(t *testing.T) {
+	routes := []*client.Route{
+		{ID: "catch-all", Protocols: []string{"http", "https"}, Paths: []string{"/"}, CreatedAt: 1},
+		{ID: "users", Protocols: []string{"http", "https"}, Paths: []string{"/users"}, CreatedAt: 2},
+		{ID: "user", Protocols: []string{"http", "https"}, Paths: []string{`~/users/\d+$`}, CreatedAt: 3},
+		{ID: "api", Protocols: []string{"http", "https"}, Hosts: []string{"*.example.com"}, CreatedAt: 4},
+		{ID: "v2", Protocols: []string{"http", "https"}, Paths: []string{"/users"}, Headers: map[string][]string{"X-Version": {"2"}}, CreatedAt: 5},
+		{ID: "secure", Protocols: []string{"https"}, Paths: []string{"/admin"}, CreatedAt: 6},
+		{ID: "expression", Protocols: []string{"http"}, Expression: `http.path == "/users"`, CreatedAt: 7},
+		{ID: "stream", Protocols: []string{"tcp"}, Destinations: []*client.RouteEndpoint{{Port: 80}}, CreatedAt: 8},
+	}
+
+	tests := []struct {
+		request  routeRequest
+		matches  []string
+		path     string
+		redirect bool
+	}{
+		{routeRequest{Protocol: "http", Path: "/orders"}, []string{"catch-all"}, "/", false},
+		{routeRequest{Protocol: "http", Path: "/users/list"}, []string{"users", "catch-all"}, "/users", false},
+		{routeRequest{Protocol: "http", Path: "/users/42"}, []string{"user", "users", "catch-all"}, `~/users/\d+$`, false},
+		{routeRequest{Protocol: "http", Path: "/users", Headers: map[string]string{"x-version": "2"}}, []string{"v2", "users", "catch-all"}, "/users", false},
+		{routeRequest{Protocol: "http", Host: "API.example.com:8000", Path: "/users"}, []string{"api", "users", "catch-all"}, "", false},
+		{routeRequest{Protocol: "http", Host: "example.com", Path: "/users"}, []string{"users", "catch-all"}, "/users", false},
+		{routeRequest{Protocol: "http", Path: "/admin"}, []string{"secure", "catch-all"}, "/admin", true},
+		{routeRequest{Protocol: "grpc", Path: "/users"}, nil, "", false},
+	}
+
+	for _, test := range tests {
+		matches := matchRouteRequest(routes, true, &test.request)
+
+		var ids []string
+		for _, match := range matches {
+			ids = append(ids, match.route.ID)
+		}
+		if strings.Join(ids, ",") != strings.Join(test.matches, ",") {
+			t.Errorf("%+v matches %v, want %v", test.request, ids, test.matches)
+			continue
+		}
+
+		if len(matches) > 0 && (matches[0].path != test.path || matches[0].redirect != test.redirect) {
+			t.Errorf("%+v selects path %q, redirect %v, want path %q, redirect %v", test.request, matches[0].path, matches[0].redirect, test.path, test.redirect)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// minimumKongVersion is the oldest Kong release the provider supports.
+var minimumKongVersion = client.Version{Major: 2}
+
+// attributeVersions maps attributes to the first Kong release supporting them.
+// Attributes of a single element block are named after the block, as in
+// config.status.
+type attributeVersions map[string]client.Version
+
+// checkKongNode makes sure the Admin API is reachable and runs a supported
+// Kong release. The node information stays cached in the client.
+func checkKongNode(ctx context.Context, c *client.Client, address string) diag.Diagnostics {
+	info, err := c.Info(ctx)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "unable to reach the Kong Admin API at " + address,
+			Detail:   err.Error(),
+		}}
+	}
+
+	version, err := info.ParsedVersion()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !version.AtLeast(minimumKongVersion) {
+		return diag.Errorf("Kong %s (%s) is not supported, the provider requires Kong %s or newer", info.Version, info.Edition(), minimumKongVersion)
+	}
+
+	return nil
+}
+
+// kongVersion returns the version of the Kong node the provider talks to.
+func kongVersion(ctx context.Context, meta interface{}) (client.Version, error) {
+	info, err := meta.(*providerMeta).client.Info(ctx)
+	if err != nil {
+		return client.Version{}, err
+	}
+
+	return info.ParsedVersion()
+}
+
+// checkAttributeVersions fails the plan when the configuration sets an
+// attribute the connected Kong node doesn't support yet.
+func checkAttributeVersions(versions attributeVersions) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+
+		version, err := kongVersion(ctx, meta)
+		if err != nil {
+			return err
+		}
+
+		attributes := make([]string, 0, len(versions))
+		for attribute := range versions {
+			attributes = append(attributes, attribute)
+		}
+		sort.Strings(attributes)
+
+		var unsupported []string
+		for _, attribute := range attributes {
+			since := versions[attribute]
+			if rawAttribute(config, attribute).IsNull() {
+				continue
+			}
+
+			if !version.AtLeast(since) {
+				unsupported = append(unsupported, fmt.Sprintf("%s (requires Kong %s)", attribute, since))
+			}
+		}
+
+		if len(unsupported) > 0 {
+			return fmt.Errorf("the connected Kong node runs %s, which doesn't support: %s", version, strings.Join(unsupported, ", "))
+		}
+
+		return nil
+	}
+}
+
+// rawAttribute returns the attribute of the raw configuration, null when it
+// or one of its enclosing blocks is absent.
+func rawAttribute(config cty.Value, attribute string) cty.Value {
+	value := config
+	for _, name := range strings.Split(attribute, ".") {
+		if value.IsNull() || !value.IsKnown() {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		if value.Type().IsListType() {
+			value = configBlock(value)
+			if value.IsNull() {
+				return value
+			}
+		}
+		if !value.Type().IsObjectType() || !value.Type().HasAttribute(name) {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		value = value.GetAttr(name)
+	}
+
+	return value
+}
+
+// supports reports whether the Kong release supports the attribute, which
+// payloads must leave out otherwise.
+func (versions attributeVersions) supports(version client.Version, attribute string) bool {
+	since, ok := versions[attribute]
+	return !ok || version.AtLeast(since)
+}
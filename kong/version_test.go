@@ -0,0 +1,85 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestTypedPluginAttributeVersions(t *testing.T) {
+	tests := []struct {
+		version string
+		config  map[string]cty.Value
+		err     string
+	}{
+		{"2.8.0", map[string]cty.Value{"per_consumer": cty.True}, ""},
+		{"2.8.0", map[string]cty.Value{"status_code_metrics": cty.True, "latency_metrics": cty.False}, "doesn't support: config.latency_metrics (requires Kong 3.0.0), config.status_code_metrics (requires Kong 3.0.0)"},
+		{"3.4.0", map[string]cty.Value{"status_code_metrics": cty.True}, ""},
+	}
+
+	for _, test := range tests {
+		var validated map[string]interface{}
+		meta := testProviderMeta(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/schemas/plugins/validate" {
+				var plugin client.Plugin
+				_ = json.NewDecoder(r.Body).Decode(&plugin)
+				validated = plugin.Configuration
+			}
+			_, _ = io.WriteString(w, fmt.Sprintf(`{"version":%q}`, test.version))
+		}, nil)
+
+		r := resourceKongPluginPrometheus()
+		config := r.CoreConfigSchema().ImpliedType().AttributeType("config").ElementType()
+
+		_, err := testPlan(r, meta, "", nil, map[string]cty.Value{
+			"config": cty.ListVal([]cty.Value{testObject(config, test.config)}),
+		})
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("Kong %s with %v: %s", test.version, test.config, err)
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("Kong %s with %v: %v, want %q", test.version, test.config, err, test.err)
+		case test.err == "":
+			for name := range test.config {
+				if _, ok := validated[name]; !ok {
+					t.Errorf("Kong %s with %v: %s not sent", test.version, test.config, name)
+				}
+			}
+		}
+	}
+}
+
+func TestBuildTypedPluginDropsUnsupportedFields(t *testing.T) {
+	r := resourceKongPluginIPRestriction()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"config": []interface{}{map[string]interface{}{
+			"allow":   []interface{}{"10.0.0.0/8"},
+			"status":  403,
+			"message": "Forbidden",
+		}},
+	})
+
+	for version, sent := range map[client.Version]bool{{Major: 2, Minor: 8}: false, {Major: 3}: true} {
+		plugin := buildTypedPlugin(d, "ip-restriction", map[string]*schema.Schema{
+			"allow":   {Type: schema.TypeList, Elem: &schema.Schema{Type: schema.TypeString}, Optional: true},
+			"status":  {Type: schema.TypeInt, Optional: true, Default: 403},
+			"message": {Type: schema.TypeString, Optional: true, Default: "Forbidden"},
+		}, ipRestrictionAttributeVersions, version)
+
+		for _, field := range []string{"status", "message"} {
+			if _, ok := plugin.Configuration[field]; ok != sent {
+				t.Errorf("Kong %s: %s sent %v, want %v", version, field, ok, sent)
+			}
+		}
+		if _, ok := plugin.Configuration["allow"]; !ok {
+			t.Errorf("Kong %s: allow not sent", version)
+		}
+	}
+}
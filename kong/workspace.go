@@ -0,0 +1,56 @@
+package kong
+
+import (
+	"context"
+	"strings"
+
+	"github.com/WeKnowSports/terraform-provider-kong/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func workspaceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "The Kong Enterprise workspace of the entity. Defaults to the workspace of the provider.",
+	}
+}
+
+// clientFor returns the client scoped to the workspace of d, or to the
+// provider workspace when d doesn't set one, and records that workspace in d.
+func clientFor(d *schema.ResourceData, meta interface{}) *client.Client {
+	c := meta.(*providerMeta).client
+
+	if workspace, ok := d.GetOk("workspace"); ok {
+		c = c.Workspace(workspace.(string))
+	}
+
+	_ = d.Set("workspace", c.WorkspaceName())
+
+	return c
+}
+
+// clientForDiff returns the client scoped to the workspace planned for d, or
+// to the provider workspace when it isn't known yet.
+func clientForDiff(d *schema.ResourceDiff, meta interface{}) *client.Client {
+	c := meta.(*providerMeta).client
+
+	if workspace, ok := d.GetOk("workspace"); ok {
+		c = c.Workspace(workspace.(string))
+	}
+
+	return c
+}
+
+// importStatePassthroughWithWorkspace imports an entity by its id, optionally
+// prefixed by its workspace as in "<workspace>/<id>".
+func importStatePassthroughWithWorkspace(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if parts := strings.SplitN(d.Id(), "/", 2); len(parts) == 2 {
+		_ = d.Set("workspace", parts[0])
+		d.SetId(parts[1])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,11 @@
+data "kong_consumer" "provisioned" {
+  custom_id = "external-user-42"
+
+  include_acl_groups  = true
+  include_credentials = true
+}
+
+resource "kong_consumer_acl_group" "provisioned_readers" {
+  consumer = data.kong_consumer.provisioned.id
+  group    = "readers"
+}
@@ -0,0 +1,14 @@
+data "kong_routes" "public" {
+  tags = ["public"]
+}
+
+data "kong_services" "platform" {
+  tags       = ["user-level", "low-priority"]
+  tags_match = "any"
+}
+
+data "kong_consumers" "all" {}
+
+output "consumer_ids" {
+  value = data.kong_consumers.all.consumers[*].id
+}
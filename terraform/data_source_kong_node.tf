@@ -0,0 +1,9 @@
+data "kong_node" "node" {}
+
+output "kong_version" {
+  value = "${data.kong_node.node.version} (${data.kong_node.node.edition})"
+}
+
+output "rate_limiting_available" {
+  value = contains(data.kong_node.node.plugins_available, "rate-limiting")
+}
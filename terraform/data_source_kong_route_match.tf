@@ -0,0 +1,19 @@
+data "kong_route_match" "users_api" {
+  protocol = "https"
+  method   = "POST"
+  host     = "api.example.com"
+  path     = "/v1/users"
+  sni      = "api.example.com"
+
+  headers = {
+    x-version = "2"
+  }
+}
+
+output "users_api_route" {
+  value = data.kong_route_match.users_api.route_name
+}
+
+output "users_api_reason" {
+  value = data.kong_route_match.users_api.reason
+}
@@ -0,0 +1,7 @@
+data "kong_service" "platform" {
+  name = "my_service"
+}
+
+data "kong_service" "tagged" {
+  filter_tags = ["user-level", "low-priority"]
+}
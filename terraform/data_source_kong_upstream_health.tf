@@ -0,0 +1,7 @@
+data "kong_upstream_health" "upstream" {
+  upstream = kong_upstream.upstream.id
+}
+
+output "unhealthy_targets" {
+  value = [for t in data.kong_upstream_health.upstream.targets : t.target if t.health == "UNHEALTHY"]
+}
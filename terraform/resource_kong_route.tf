@@ -27,3 +27,37 @@ resource "kong_route" "route" {
   tags                       = ["user-level", "low-priority"]
 
 }
+
+resource "kong_service" "tcp_service" {
+  name     = "my-tcp-service"
+  protocol = "tcp"
+  host     = "db.internal"
+  port     = 5432
+}
+
+resource "kong_route" "tcp_route" {
+
+  service = kong_service.tcp_service.id
+
+  name      = "my-tcp-route"
+  protocols = ["tcp"]
+
+  source {
+    ip = "10.0.0.0/8"
+  }
+
+  destination {
+    port = 5432
+  }
+}
+
+# Requires Kong 3.0 or newer with router_flavor = expressions.
+resource "kong_route" "expression_route" {
+
+  service = kong_service.service.id
+
+  name       = "my-expression-route"
+  protocols  = ["http", "https"]
+  expression = "http.path ^= \"/api/\" && (http.method == \"GET\" || http.method == \"HEAD\")"
+  priority   = 100
+}
@@ -23,3 +23,15 @@ resource "kong_service" "service" {
   //  ca_certificates = ["4e3ad2e4-0bc4-4638-8e34-c84a417ba39b", "51e77dc2-8f3e-4afa-9d0e-0e3bbbcfd515"]
 
 }
+
+resource "kong_service" "mtls_service" {
+  name     = "my_mtls_service"
+  protocol = "https"
+  host     = "backend.example.com"
+  port     = 443
+
+  client_certificate = kong_certificate.certificate.id
+  tls_verify         = true
+  tls_verify_depth   = 2
+  // ca_certificates  = [kong_ca_certificate.ca_certificate.id]
+}
@@ -4,3 +4,17 @@ resource "kong_target" "target" {
   weight   = 100
   tags     = ["user-level", "low-priority"]
 }
+
+resource "kong_target" "drained_target" {
+  upstream = kong_upstream.upstream.id
+  target   = "example.com:80"
+
+  // Take the target out of the balancer and let in-flight requests complete
+  // for a minute before deleting it.
+  drain        = true
+  drain_period = 60
+
+  timeouts {
+    delete = "5m"
+  }
+}
@@ -0,0 +1,73 @@
+resource "kong_plugin_rate_limiting" "typed_rate_limiting_on_service" {
+  service = kong_service.service.id
+
+  config {
+    minute = 60
+    hour   = 1000
+    policy = "local"
+  }
+}
+
+resource "kong_plugin_cors" "cors_on_route" {
+  route = kong_route.route.id
+
+  config {
+    origins     = ["https://example.com"]
+    methods     = ["GET", "POST"]
+    credentials = true
+    max_age     = 3600
+  }
+}
+
+resource "kong_plugin_key_auth" "key_auth_on_service" {
+  service = kong_service.service.id
+
+  config {
+    key_names        = ["x-api-key"]
+    hide_credentials = true
+  }
+}
+
+resource "kong_plugin_jwt" "jwt_on_route" {
+  route = kong_route.route.id
+
+  config {
+    claims_to_verify   = ["exp"]
+    maximum_expiration = 3600
+  }
+}
+
+resource "kong_plugin_acl" "acl_on_route" {
+  route = kong_route.route.id
+
+  config {
+    allow = ["readers"]
+  }
+}
+
+resource "kong_plugin_request_transformer" "request_transformer_on_service" {
+  service = kong_service.service.id
+
+  config {
+    add {
+      headers = ["x-source:kong"]
+    }
+    remove {
+      querystring = ["debug"]
+    }
+  }
+}
+
+resource "kong_plugin_prometheus" "typed_prometheus" {
+  config {
+    per_consumer = true
+  }
+}
+
+resource "kong_plugin_ip_restriction" "ip_restriction_on_route" {
+  route = kong_route.route.id
+
+  config {
+    allow = ["10.0.0.0/8", "192.168.1.10"]
+  }
+}